@@ -0,0 +1,160 @@
+package ai
+
+import (
+	"errors"
+	"golang-games/PuzzleBlock/boardmodel"
+	"math/rand"
+	"strings"
+)
+
+// maxStuckMoves is the number of moves in a row that can fail to move the active block before the player gives up on its plan and drops the block where it is
+const maxStuckMoves = 3
+
+// Controls is what the player presses keys on - the gameboard of the game it is playing
+type Controls interface {
+	MoveActiveBlock(d string)
+}
+
+// Difficulty is how well the computer plays
+// LookAhead plays the next block in the queue out after each placement, MistakeChance is how often a placement is picked at random and MoveDelay is the milliseconds between key presses
+type Difficulty struct {
+	Name          string
+	LookAhead     bool
+	MistakeChance float64
+	MoveDelay     float64
+}
+
+// Easy is a slow player that only looks at the block it is placing and often gets it wrong
+func Easy() Difficulty {
+	return Difficulty{Name: "Easy", LookAhead: false, MistakeChance: 0.3, MoveDelay: 300}
+}
+
+// Normal is a player that only looks at the block it is placing and sometimes gets it wrong
+func Normal() Difficulty {
+	return Difficulty{Name: "Normal", LookAhead: false, MistakeChance: 0.1, MoveDelay: 150}
+}
+
+// Hard is a quick player that plans for the next block as well and never makes a mistake
+func Hard() Difficulty {
+	return Difficulty{Name: "Hard", LookAhead: true, MistakeChance: 0, MoveDelay: 60}
+}
+
+// Difficulties returns every difficulty, easiest first
+func Difficulties() []Difficulty {
+	return []Difficulty{Easy(), Normal(), Hard()}
+}
+
+// FindDifficulty returns the difficulty called 'name', in any case
+func FindDifficulty(name string) (Difficulty, error) {
+	for _, d := range Difficulties() {
+		if strings.EqualFold(d.Name, name) == true {
+			return d, nil
+		}
+	}
+	return Difficulty{}, errors.New("ai: no difficulty called " + name + ", expected Easy, Normal or Hard")
+}
+
+// Player is a computer player that reads a board and presses keys to drop each new block where it scores best
+type Player struct {
+	Board      *boardmodel.Board
+	Controls   Controls
+	Difficulty Difficulty
+	Rand       *rand.Rand
+	Plan       Placement
+	Planned    int
+	MoveTimer  float64
+	PrevPos    boardmodel.Pos
+	PrevTurns  int
+	StuckMoves int
+}
+
+// NewPlayer is a computer player constructor - it reads 'board' and moves its active block through 'controls', and its mistakes are drawn from a source seeded with 'seed'
+func NewPlayer(board *boardmodel.Board, controls Controls, difficulty Difficulty, seed int64) *Player {
+
+	p := &Player{}
+
+	p.Board = board
+
+	p.Controls = controls
+
+	p.Difficulty = difficulty
+
+	p.Rand = rand.New(rand.NewSource(seed))
+
+	p.Reset()
+
+	return p
+}
+
+// Reset forgets the plan for the current block - it should be called when a new game is started on the board
+func (p *Player) Reset() {
+	p.Plan = Placement{}
+	p.Planned = -1
+	p.MoveTimer = 0
+	p.PrevPos = boardmodel.Pos{X: -1, Y: -1}
+	p.PrevTurns = 0
+	p.StuckMoves = 0
+}
+
+// Update presses a key once every move delay of the difficulty - a new plan is made whenever a new block comes into play
+func (p *Player) Update(time float64) {
+	p.MoveTimer += time
+	for p.MoveTimer >= p.Difficulty.MoveDelay {
+		p.MoveTimer -= p.Difficulty.MoveDelay
+		p.move()
+	}
+}
+
+// move presses the next key of the plan - the pair is turned first, then moved across, then dropped
+func (p *Player) move() {
+	b := p.Board
+	if b.GameOver == true || b.GameOverPausing == true || b.HasPiece(0) == false {
+		return
+	}
+
+	if b.PiecesUsed != p.Planned {
+		p.plan()
+	}
+
+	// A block that has not moved or turned since the last key press is stuck against something the plan did not see coming
+	pos := b.Pieces[0].Pos
+	turns := turnsOf(b)
+	if pos == p.PrevPos && turns == p.PrevTurns {
+		p.StuckMoves++
+	} else {
+		p.StuckMoves = 0
+	}
+	p.PrevPos = pos
+	p.PrevTurns = turns
+
+	switch {
+	case p.StuckMoves >= maxStuckMoves:
+		p.Controls.MoveActiveBlock("drop")
+	case b.HasPartner(0) == true && turns != p.Plan.Turns:
+		p.Controls.MoveActiveBlock("rotate_cw")
+	case pos.X < p.Plan.Column:
+		p.Controls.MoveActiveBlock("right")
+	case pos.X > p.Plan.Column:
+		p.Controls.MoveActiveBlock("left")
+	default:
+		p.Controls.MoveActiveBlock("drop")
+	}
+}
+
+// plan picks where the active block is going - the best placement found, or a random one when the player makes a mistake
+func (p *Player) plan() {
+	p.Planned = p.Board.PiecesUsed
+	p.PrevPos = boardmodel.Pos{X: -1, Y: -1}
+	p.StuckMoves = 0
+
+	found := placements(p.Board, p.Difficulty.LookAhead)
+	if len(found) == 0 {
+		p.Plan = Placement{Column: p.Board.Pieces[0].Pos.X, Turns: turnsOf(p.Board)}
+		return
+	}
+
+	p.Plan = found[0]
+	if p.Rand.Float64() < p.Difficulty.MistakeChance {
+		p.Plan = found[p.Rand.Intn(len(found))]
+	}
+}
@@ -0,0 +1,101 @@
+package ai
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"testing"
+)
+
+// soakFrames is the most frames a test game lasts
+const soakFrames = 6000
+
+// stagedBoard returns a board with a red block in play at the top of the middle column, and two red blocks on the floor to its right with the middle of the floor empty
+// The next block is yellow, so looking ahead finds nothing better to wait for
+func stagedBoard() *boardmodel.Board {
+	b := boardmodel.NewBoard(5, 10, 1)
+	b.Step()
+
+	a := b.Pieces[0].Pos
+	b.BlockColors[a.Y][a.X] = boardmodel.Red
+	b.Queue[0] = boardmodel.Yellow
+
+	floor := []boardmodel.Color{boardmodel.Green, boardmodel.Blue, 0, boardmodel.Red, boardmodel.Red}
+	for i, c := range floor {
+		if i == 2 {
+			continue
+		}
+		b.BlockStates[9][i] = boardmodel.Inactive
+		b.BlockColors[9][i] = c
+	}
+	return b
+}
+
+func TestBestPlacementMakesTheMatch(t *testing.T) {
+	for _, lookAhead := range []bool{false, true} {
+		found := placements(stagedBoard(), lookAhead)
+		if len(found) != 5 {
+			t.Fatalf("look-ahead %v: found %d placements, expected one for each of the 5 columns", lookAhead, len(found))
+		}
+		if found[0].Column != 2 || found[0].Turns != 0 {
+			t.Errorf("look-ahead %v: best placement is column %d with %d turns, expected column 2 to finish the red row", lookAhead, found[0].Column, found[0].Turns)
+		}
+	}
+}
+
+func TestPlayerDropsOnTheBestPlacement(t *testing.T) {
+	b := stagedBoard()
+	p := NewPlayer(b, b, Hard(), 1)
+
+	for f := 0; f < 1000 && b.ScoreValue == 0; f++ {
+		p.Update(boardmodel.FrameTime)
+		b.Step()
+	}
+	if b.ScoreValue == 0 {
+		t.Errorf("the player never finished the red row")
+	}
+	for i := 3; i < 5; i++ {
+		if b.BlockStates[9][i] == boardmodel.Inactive && b.BlockColors[9][i] == boardmodel.Red {
+			t.Errorf("the red block at {%d 9} was left on the floor", i)
+		}
+	}
+}
+
+func TestEasyScoresNoMoreThanHard(t *testing.T) {
+	for seed := int64(1); seed <= 3; seed++ {
+		scores := map[string]int{}
+		for _, d := range []Difficulty{Easy(), Hard()} {
+			results, err := Soak(boardmodel.NewBoard(5, 10, seed), 1, d, seed, soakFrames)
+			if err != nil {
+				t.Fatal(err)
+			}
+			scores[d.Name] = results[0].Score
+		}
+		if scores["Easy"] > scores["Hard"] {
+			t.Errorf("seed %d: Easy scored %d and Hard %d, expected Hard to do at least as well", seed, scores["Easy"], scores["Hard"])
+		}
+	}
+}
+
+func TestSoakPlaysBackTheSame(t *testing.T) {
+	for _, pairs := range []bool{false, true} {
+		b := boardmodel.NewBoard(5, 10, 7)
+		b.PairMode = pairs
+
+		results, err := Soak(b, 2, Normal(), 7, soakFrames)
+		if err != nil {
+			t.Errorf("pairs %v: %v", pairs, err)
+		}
+		if len(results) != 2 {
+			t.Errorf("pairs %v: played %d games, expected 2", pairs, len(results))
+		}
+	}
+}
+
+func TestFindDifficulty(t *testing.T) {
+	d, err := FindDifficulty("hard")
+	if err != nil || d != Hard() {
+		t.Errorf("found %+v and %v, expected Hard", d, err)
+	}
+	if _, err = FindDifficulty("Impossible"); err == nil {
+		t.Errorf("found a difficulty called Impossible")
+	}
+}
@@ -0,0 +1,37 @@
+package ai
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+)
+
+// Hint returns the cells the active block of player 0 lands on where it makes the biggest match straight away, chains included
+// Each placement is played out on a copy of the board, so the matches are found by the rules of the game itself - false is returned if no placement the block can reach makes a match
+func Hint(b *boardmodel.Board) ([]boardmodel.Pos, bool) {
+	if b.HasPiece(0) == false {
+		return nil, false
+	}
+
+	current := currentPiece(b)
+	turns := 1
+	if current.Pair == true {
+		turns = len(offsets)
+	}
+
+	var best []boardmodel.Pos
+	bestPoints := 0
+	for t := 0; t < turns; t++ {
+		for column := 0; column < b.Width; column++ {
+			if reachable(b, column, t) == false {
+				continue
+			}
+			s := sandbox(b)
+			cells := place(s, current, column, t)
+			if cells != nil && s.ScoreValue > bestPoints {
+				best = cells
+				bestPoints = s.ScoreValue
+			}
+		}
+	}
+
+	return best, best != nil
+}
@@ -0,0 +1,290 @@
+package ai
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"sort"
+)
+
+// maxSettleFrames is the most frames a placement is played out for before it is scored - a board that has not settled by then is scored as it is
+const maxSettleFrames = 500
+
+// lookAheadCandidates is the number of the best placements of the current piece that are tried again with the next piece dropped after them
+const lookAheadCandidates = 5
+
+// offsets are where the partner of a pair sits next to the pivot, one quarter turn clockwise after another starting from the way a pair is spawned
+var offsets = []boardmodel.Pos{{X: 0, Y: -1}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: -1, Y: 0}}
+
+// Placement is a column for the active block to be dropped in - Turns is the number of clockwise turns a pair needs first, and is always 0 for a single block
+type Placement struct {
+	Column int
+	Turns  int
+	Value  float64
+}
+
+// piece is the colors of a single block or a pair that is about to be placed
+type piece struct {
+	Pivot   boardmodel.Color
+	Partner boardmodel.Color
+	Pair    bool
+}
+
+// turnsOf returns the number of clockwise turns the pair of player 0 has been given since it was spawned
+func turnsOf(b *boardmodel.Board) int {
+	p := b.Pieces[0]
+	offset := boardmodel.Pos{X: p.Partner.X - p.Pos.X, Y: p.Partner.Y - p.Pos.Y}
+	for k := range offsets {
+		if offsets[k] == offset {
+			return k
+		}
+	}
+	return 0
+}
+
+// currentPiece returns the colors of the active block of player 0
+func currentPiece(b *boardmodel.Board) piece {
+	p := b.Pieces[0]
+	current := piece{Pivot: b.BlockColors[p.Pos.Y][p.Pos.X]}
+	if b.HasPartner(0) == true {
+		current.Partner = b.BlockColors[p.Partner.Y][p.Partner.X]
+		current.Pair = true
+	}
+	return current
+}
+
+// nextPiece returns the colors of the block that will be spawned after the active one - a pair takes the first color in the queue for its pivot
+func nextPiece(b *boardmodel.Board) piece {
+	if b.PairMode == true {
+		return piece{Pivot: b.Queue[0], Partner: b.Queue[1], Pair: true}
+	}
+	return piece{Pivot: b.Queue[0]}
+}
+
+// reachable returns true if the active block of player 0 can slide across to 'column' with 'turns' turns at the height it is at
+func reachable(b *boardmodel.Board, column, turns int) bool {
+	p := b.Pieces[0]
+	paths := []boardmodel.Pos{{X: 0, Y: p.Pos.Y}}
+	if b.HasPartner(0) == true {
+		o := offsets[turns]
+		paths = append(paths, boardmodel.Pos{X: o.X, Y: p.Pos.Y + o.Y})
+	}
+
+	from, to := p.Pos.X, column
+	if from > to {
+		from, to = to, from
+	}
+	for _, path := range paths {
+		if path.Y < 0 || path.Y >= b.Height || from+path.X < 0 || to+path.X >= b.Width {
+			return false
+		}
+		for i := from + path.X; i <= to+path.X; i++ {
+			if b.BlockStates[path.Y][i] == boardmodel.Inactive {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// sandbox returns a copy of the settled blocks of 'b' that placements can be played out on without touching the board
+// Blocks fall a row every frame and clears do not pause the copy, so that a placement plays out in a few frames, and the level stays where it is
+func sandbox(b *boardmodel.Board) *boardmodel.Board {
+	s := boardmodel.NewBoard(b.Width, b.Height, b.Seed)
+	s.Rules = b.Rules
+	s.Levels = b.Levels
+	s.LevelValue = b.LevelValue
+	s.MaxLevelValue = b.LevelValue
+	s.DeGrayValue = b.DeGrayValue
+	s.BlockFallingTime = 0
+	s.BlocksFallingTime = 0
+
+	for j := range b.BlockStates {
+		for i := range b.BlockStates[j] {
+			if b.BlockStates[j][i] == boardmodel.Inactive {
+				s.BlockStates[j][i] = boardmodel.Inactive
+				s.BlockColors[j][i] = b.BlockColors[j][i]
+			}
+		}
+	}
+
+	return s
+}
+
+// landing returns the row a block dropped into 'column' of 's' comes to rest on, or -1 if the column is full
+func landing(s *boardmodel.Board, column int) int {
+	if s.BlockStates[0][column] != boardmodel.Empty {
+		return -1
+	}
+	j := 0
+	for j+1 < s.Height && s.BlockStates[j+1][column] == boardmodel.Empty {
+		j++
+	}
+	return j
+}
+
+// place drops 'p' into 'column' of 's' with 'turns' turns and plays it out until the board settles
+// The cells the blocks landed on are returned, pivot first, or nil if the piece does not fit
+func place(s *boardmodel.Board, p piece, column, turns int) []boardmodel.Pos {
+	blocks := []boardmodel.Pos{{X: column, Y: 0}}
+	colors := []boardmodel.Color{p.Pivot}
+	if p.Pair == true {
+		o := offsets[turns]
+		partner := boardmodel.Pos{X: column + o.X, Y: 0}
+		if partner.X < 0 || partner.X >= s.Width {
+			return nil
+		}
+		blocks = append(blocks, partner)
+		colors = append(colors, p.Partner)
+	}
+
+	// The lower half of a stacked pair lands first
+	order := []int{0, 1}[:len(blocks)]
+	if p.Pair == true && offsets[turns].Y > 0 {
+		order = []int{1, 0}
+	}
+
+	for _, k := range order {
+		j := landing(s, blocks[k].X)
+		if j == -1 {
+			return nil
+		}
+		blocks[k].Y = j
+		s.BlockStates[j][blocks[k].X] = boardmodel.Inactive
+		s.BlockColors[j][blocks[k].X] = colors[k]
+	}
+
+	for frame := 0; frame < maxSettleFrames && s.GameOver == false; frame++ {
+		s.Step()
+		clearPieces(s)
+		if s.Settled() == true {
+			break
+		}
+	}
+	return blocks
+}
+
+// clearPieces takes away any block the copy spawned while it was played out, so that only the placement being scored ends up on it
+func clearPieces(s *boardmodel.Board) {
+	for _, p := range s.ActiveBlocks() {
+		s.BlockStates[p.Y][p.X] = boardmodel.Empty
+	}
+	s.SetPlayers(len(s.Pieces))
+}
+
+// evaluate scores a board that a placement has been played out on - points made, blocks lined up for later matches and a low stack count for it, a high stack against it
+func evaluate(s *boardmodel.Board, points int) float64 {
+	value := float64(points)
+
+	for i := 0; i < s.Width; i++ {
+		height := 0
+		for j := 0; j < s.Height; j++ {
+			if s.BlockStates[j][i] == boardmodel.Inactive {
+				height = s.Height - j
+				break
+			}
+		}
+		value -= float64(height*height) * 0.5
+		if height >= s.Height-2 {
+			value -= 200
+		}
+
+		// New blocks need the top of the spawn column free to come in and to get across the board
+		if i == s.SpawnColumn(0) && height >= s.Height-3 {
+			value -= 5000
+		}
+	}
+
+	// Settled blocks next to a block they match are the start of a later clear
+	directions := []boardmodel.Pos{{X: 1, Y: 0}, {X: 0, Y: 1}}
+	if s.Rules.Diagonals == true && s.Rules.Mode == boardmodel.LineMatches {
+		directions = append(directions, boardmodel.Pos{X: 1, Y: 1}, boardmodel.Pos{X: -1, Y: 1})
+	}
+	for j := range s.BlockStates {
+		for i := range s.BlockStates[j] {
+			if s.BlockStates[j][i] != boardmodel.Inactive {
+				continue
+			}
+			for _, d := range directions {
+				x, y := i+d.X, j+d.Y
+				if x >= 0 && x < s.Width && y < s.Height && s.BlockStates[y][x] == boardmodel.Inactive &&
+					s.Rules.Matches(s.BlockColors[j][i], s.BlockColors[y][x]) == true {
+					value += 4
+				}
+			}
+		}
+	}
+
+	if s.GameOverPausing == true || s.GameOver == true {
+		value -= 10000
+	}
+
+	return value
+}
+
+// placements returns every placement of the active block of player 0 that it can reach, best first
+// With look-ahead the best of them are scored again with the next piece dropped as well as it can be after them
+func placements(b *boardmodel.Board, lookAhead bool) []Placement {
+	current := currentPiece(b)
+	turns := 1
+	if current.Pair == true {
+		turns = len(offsets)
+	}
+
+	found := []Placement{}
+	for t := 0; t < turns; t++ {
+		for column := 0; column < b.Width; column++ {
+			if reachable(b, column, t) == false {
+				continue
+			}
+			s := sandbox(b)
+			if place(s, current, column, t) == nil {
+				continue
+			}
+			found = append(found, Placement{Column: column, Turns: t, Value: evaluate(s, s.ScoreValue)})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].Value > found[j].Value
+	})
+
+	if lookAhead == false {
+		return found
+	}
+
+	next := nextPiece(b)
+	nextTurns := 1
+	if next.Pair == true {
+		nextTurns = len(offsets)
+	}
+	for k := 0; k < len(found) && k < lookAheadCandidates; k++ {
+		first := sandbox(b)
+		place(first, current, found[k].Column, found[k].Turns)
+
+		best := evaluate(first, first.ScoreValue) - 10000
+		for t := 0; t < nextTurns; t++ {
+			for column := 0; column < b.Width; column++ {
+				s := sandbox(first)
+				if place(s, next, column, t) == nil {
+					continue
+				}
+				value := evaluate(s, first.ScoreValue+s.ScoreValue)
+				if value > best {
+					best = value
+				}
+			}
+		}
+		found[k].Value = best
+	}
+	sort.SliceStable(found[:minInt(len(found), lookAheadCandidates)], func(i, j int) bool {
+		return found[i].Value > found[j].Value
+	})
+
+	return found
+}
+
+// minInt returns the smaller of 'a' and 'c'
+func minInt(a, c int) int {
+	if a < c {
+		return a
+	}
+	return c
+}
@@ -0,0 +1,32 @@
+package ai
+
+import (
+	"errors"
+	"golang-games/PuzzleBlock/boardmodel"
+	"golang-games/PuzzleBlock/replay"
+	"strconv"
+)
+
+// Soak has the computer play 'games' games in a row on 'b', without drawing anything, under the settings the board already has
+// Each game ends when it is over or after 'maxFrames' frames, and is played back from its recording to make sure it comes out the same - the results of every game are returned
+func Soak(b *boardmodel.Board, games int, difficulty Difficulty, seed int64, maxFrames int) ([]boardmodel.Results, error) {
+	player := NewPlayer(b, b, difficulty, seed)
+	results := make([]boardmodel.Results, 0, games)
+
+	for k := 0; k < games; k++ {
+		b.StartRecording(seed + int64(k))
+		player.Reset()
+
+		for b.GameOver == false && b.Frame < maxFrames {
+			player.Update(boardmodel.FrameTime)
+			b.Step()
+		}
+		results = append(results, b.Results())
+
+		if replay.NewReplay(b).Simulate().Hash() != b.Hash() {
+			return results, errors.New("soak: game " + strconv.Itoa(k+1) + " with seed " + strconv.FormatInt(seed+int64(k), 10) + " played back differently")
+		}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,224 @@
+package boardmodel
+
+// ProccessBlockMovement handles the logic of moving the active block of player 'k'
+func (b *Board) ProccessBlockMovement(k int, d string) {
+
+	active := &b.Pieces[k].Pos
+	prevActive := *active
+
+	switch d {
+	case "Y++":
+		if active.Y >= 0 && active.Y < b.Height && active.Y+1 < b.Height &&
+			b.BlockStates[active.Y+1][active.X] == Empty {
+			active.Y++
+		}
+	case "Y--":
+		if active.Y > 0 && active.Y <= b.Height {
+			active.Y--
+		}
+	case "X++":
+		if (active.X >= 0 && active.X < b.Width-1) &&
+			b.BlockStates[active.Y][active.X+1] == Empty {
+			active.X++
+		}
+	case "X--":
+		if (active.X > 0 && active.X <= b.Width-1) &&
+			b.BlockStates[active.Y][active.X-1] == Empty {
+			active.X--
+		}
+	default:
+		panic("ERROR: ProccessBlockMovement requires input of Y++, Y--, X++, X--. You have: " + d)
+	}
+
+	if active.Y < b.Height && active.Y >= 0 {
+		// Set the old block to empty
+		b.BlockStates[prevActive.Y][prevActive.X] = Empty
+
+		// Set the current block to active
+		b.BlockColors[active.Y][active.X] = b.BlockColors[prevActive.Y][prevActive.X]
+		b.BlockStates[active.Y][active.X] = Active
+	}
+}
+
+// MoveActiveBlock changes around the board based on the user pressed key - the key is ignored while a recording is being played back
+func (b *Board) MoveActiveBlock(d string) {
+	b.MovePiece(0, d)
+}
+
+// MovePiece moves the piece of player 'k' based on the key they pressed - the key is ignored while a recording is being played back
+func (b *Board) MovePiece(k int, d string) {
+	if b.PlayingBack == false {
+		b.applyInput(k, d)
+	}
+}
+
+// applyInput records an input if the board is recording and then applies it to the piece of player 'k' - an automatic fall moves every piece
+func (b *Board) applyInput(k int, d string) {
+	if b.Recording == true {
+		b.Inputs = append(b.Inputs, Input{Frame: b.Frame, Move: d, Player: k})
+	}
+
+	if d == "fall" {
+		for _, l := range b.pieceOrder() {
+			b.movePiece(l, d)
+		}
+	} else if k >= 0 && k < len(b.Pieces) {
+		b.movePiece(k, d)
+	}
+
+	// Automatic falls restart the level timing even if the block could not move
+	if d == "fall" {
+		b.LevelFall = true
+		b.LevelFallingTimer = 0
+	}
+}
+
+// movePiece applies a single move to the piece of player 'k'
+func (b *Board) movePiece(k int, d string) {
+	if b.GameOverPausing == false && b.HasPartner(k) == true {
+		switch d {
+		case "down":
+			b.MovePair(k, 0, 1)
+			b.LevelFallingTimer = 0
+		case "left":
+			b.MovePair(k, -1, 0)
+		case "right":
+			b.MovePair(k, 1, 0)
+		case "fall":
+			b.MovePair(k, 0, 1)
+		case "rotate_cw":
+			b.RotatePair(k, true)
+		case "rotate_ccw":
+			b.RotatePair(k, false)
+		case "drop":
+			b.HardDrop(k)
+		default:
+		}
+	} else if b.GameOverPausing == false {
+		switch d {
+		case "up":
+			//b.ProccessBlockMovement(k, "Y--")
+		case "down":
+			b.ProccessBlockMovement(k, "Y++")
+			b.LevelFallingTimer = 0
+		case "left":
+			b.ProccessBlockMovement(k, "X--")
+		case "right":
+			b.ProccessBlockMovement(k, "X++")
+		case "fall":
+			b.ProccessBlockMovement(k, "Y++")
+		case "drop":
+			b.HardDrop(k)
+		case "hold":
+			b.HoldActiveBlock(k)
+		default:
+		}
+	}
+}
+
+// HoldActiveBlock puts the active block of player 'k' into the hold cell - a block that was already held comes back into play at the top of the board, otherwise the next block is spawned
+// Only one hold is allowed for each block that is spawned, and pairs can not be held - players who share a board share the hold cell too
+func (b *Board) HoldActiveBlock(k int) {
+	piece := &b.Pieces[k]
+	if piece.HoldUsed == true || b.HasPiece(k) == false || b.HasPartner(k) == true {
+		return
+	}
+
+	// The last piece of a puzzle can only be swapped for a held one, since there is nothing left to spawn in its place
+	if b.Holding == false && b.PiecesLeft() == 0 {
+		return
+	}
+
+	activeColor := b.BlockColors[piece.Pos.Y][piece.Pos.X]
+	b.BlockStates[piece.Pos.Y][piece.Pos.X] = Empty
+
+	if b.Holding == true {
+		// Bring the held block back at the spawn point, or where the active block was if the spawn point is blocked
+		spawn := b.SpawnColumn(k)
+		if b.BlockStates[0][spawn] == Empty {
+			piece.Pos = Pos{spawn, 0}
+		}
+		b.BlockStates[piece.Pos.Y][piece.Pos.X] = Active
+		b.BlockColors[piece.Pos.Y][piece.Pos.X] = b.HoldColor
+	} else {
+		// Let the next block spawn in place of the active one
+		piece.Pos = Pos{-1, -1}
+	}
+
+	b.HoldColor = activeColor
+	b.Holding = true
+	piece.HoldUsed = true
+	b.LevelFallingTimer = 0
+}
+
+// GhostBlocks returns where each of the active blocks will come to rest if they are dropped straight down, in the same order as ActiveBlocks
+func (b *Board) GhostBlocks() []Pos {
+	ghosts := []Pos{}
+	for k := range b.Pieces {
+		ghosts = append(ghosts, b.PieceGhostBlocks(k)...)
+	}
+	return ghosts
+}
+
+// PieceGhostBlocks returns where each of the blocks of player 'k' will come to rest if they are dropped straight down, in the same order as PieceBlocks
+// The halves of a pair are dropped separately, since they detach and fall on their own once one of them lands, and the pieces of other players are in the way like settled blocks
+func (b *Board) PieceGhostBlocks(k int) []Pos {
+	active := b.PieceBlocks(k)
+	ghosts := make([]Pos, len(active))
+
+	// Drop the lowest block first so that a block above it in the same column comes to rest on top of it
+	order := []int{}
+	for m := range active {
+		if len(order) > 0 && active[m].Y > active[order[0]].Y {
+			order = append([]int{m}, order...)
+		} else {
+			order = append(order, m)
+		}
+	}
+
+	for n, m := range order {
+		p := active[m]
+		for p.Y+1 < b.Height && (b.BlockStates[p.Y+1][p.X] == Empty || b.isActiveBlock(k, Pos{p.X, p.Y + 1})) {
+			// Stop on top of a block that has already been dropped
+			taken := false
+			for _, l := range order[:n] {
+				if ghosts[l] == (Pos{p.X, p.Y + 1}) {
+					taken = true
+				}
+			}
+			if taken == true {
+				break
+			}
+			p.Y++
+		}
+		ghosts[m] = p
+	}
+
+	return ghosts
+}
+
+// HardDrop sends the active block of player 'k' straight down to where it lands - a pair drops until one of its halves lands
+func (b *Board) HardDrop(k int) {
+	active := b.PieceBlocks(k)
+	if len(active) == 0 {
+		return
+	}
+
+	ghosts := b.PieceGhostBlocks(k)
+	distance := b.Height
+	for m := range active {
+		if ghosts[m].Y-active[m].Y < distance {
+			distance = ghosts[m].Y - active[m].Y
+		}
+	}
+
+	for m := 0; m < distance; m++ {
+		if b.HasPartner(k) == true {
+			b.MovePair(k, 0, 1)
+		} else {
+			b.ProccessBlockMovement(k, "Y++")
+		}
+	}
+
+	b.LevelFallingTimer = 0
+}
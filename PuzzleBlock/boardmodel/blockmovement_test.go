@@ -0,0 +1,109 @@
+package boardmodel_test
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"testing"
+)
+
+// stepUntil steps 'b' until 'done' returns true, failing the test if it takes more than 'frames' frames
+func stepUntil(t *testing.T, b *boardmodel.Board, frames int, done func() bool) {
+	for f := 0; done() == false; f++ {
+		if f >= frames || b.GameOver == true {
+			t.Fatalf("gave up after %d frames", f)
+		}
+		b.Step()
+	}
+}
+
+// activeColor returns the color of the active block of 'b'
+func activeColor(b *boardmodel.Board) boardmodel.Color {
+	return b.BlockColors[b.Pieces[0].Pos.Y][b.Pieces[0].Pos.X]
+}
+
+func TestHoldSwapsOncePerBlock(t *testing.T) {
+	b := emptyBoard(1)
+	b.Step()
+	if b.Pieces[0].Pos.Y != 0 {
+		t.Fatalf("no block spawned on the first frame")
+	}
+	first := activeColor(b)
+	next := b.NextColor()
+
+	// The first hold puts the block away and lets the next one in
+	b.MoveActiveBlock("hold")
+	if b.Holding == false || b.HoldColor != first || b.Pieces[0].Pos.X != -1 {
+		t.Fatalf("holding %v %d with the active block at %v, expected %d held and no active block", b.Holding, b.HoldColor, b.Pieces[0].Pos, first)
+	}
+	b.Step()
+	if b.Pieces[0].Pos.Y != 0 || activeColor(b) != next {
+		t.Fatalf("block %v after the hold, expected the next block %d at the top", b.Pieces[0].Pos, next)
+	}
+
+	// Holding the new block swaps the held one back in at the top of the board
+	b.MoveActiveBlock("right")
+	b.MoveActiveBlock("hold")
+	if b.Pieces[0].Pos != (boardmodel.Pos{b.SpawnColumn(0), 0}) || activeColor(b) != first || b.HoldColor != next {
+		t.Fatalf("swapped to %d at %v holding %d, expected %d at the top holding %d", activeColor(b), b.Pieces[0].Pos, b.HoldColor, first, next)
+	}
+
+	// The block that came back can not be held again until it lands
+	b.MoveActiveBlock("hold")
+	if activeColor(b) != first || b.HoldColor != next || b.Pieces[0].HoldUsed == false {
+		t.Errorf("held the swapped block a second time")
+	}
+
+	// The block spawned after it lands can hold again
+	stepUntil(t, b, 10000, func() bool { return b.Pieces[0].HoldUsed == false })
+	third := activeColor(b)
+	b.MoveActiveBlock("hold")
+	if b.Pieces[0].Pos.Y != 0 || activeColor(b) != next || b.HoldColor != third {
+		t.Errorf("swapped to %d holding %d, expected %d holding %d", activeColor(b), b.HoldColor, next, third)
+	}
+}
+
+func TestHardDropLandsOnTheGhost(t *testing.T) {
+	b := emptyBoard(1)
+	place(b, boardmodel.Gray, boardmodel.Pos{2, 9}, boardmodel.Pos{2, 8}, boardmodel.Pos{2, 7})
+	b.Step()
+	if b.Pieces[0].Pos != (boardmodel.Pos{2, 0}) {
+		t.Fatalf("block spawned at %v, expected {2 0}", b.Pieces[0].Pos)
+	}
+
+	ghost := b.GhostBlocks()
+	if len(ghost) != 1 || ghost[0] != (boardmodel.Pos{2, 6}) {
+		t.Fatalf("ghost is %v, expected [{2 6}]", ghost)
+	}
+	b.MoveActiveBlock("drop")
+	if b.Pieces[0].Pos != ghost[0] {
+		t.Errorf("dropped to %v, expected the ghost at %v", b.Pieces[0].Pos, ghost[0])
+	}
+}
+
+func TestHardDropLandsPairsOnTheirGhosts(t *testing.T) {
+	tests := []struct {
+		name           string
+		settled        []boardmodel.Pos
+		pivot, partner boardmodel.Pos
+	}{
+		{"standing up", nil, boardmodel.Pos{2, 1}, boardmodel.Pos{2, 0}},
+		{"lying down on a flat floor", []boardmodel.Pos{{1, 9}, {2, 9}}, boardmodel.Pos{1, 0}, boardmodel.Pos{2, 0}},
+		{"lying down on a step", []boardmodel.Pos{{2, 9}, {2, 8}}, boardmodel.Pos{1, 0}, boardmodel.Pos{2, 0}},
+	}
+
+	for _, test := range tests {
+		b := emptyBoard(1)
+		place(b, boardmodel.Gray, test.settled...)
+		setPair(b, test.pivot, test.partner)
+		ghosts := b.GhostBlocks()
+
+		// Both halves end up on their ghosts, the one left hanging once it has fallen on its own
+		b.MoveActiveBlock("drop")
+		b.Step()
+		stepUntil(t, b, 10000, func() bool {
+			return b.BlockStates[ghosts[0].Y][ghosts[0].X] == boardmodel.Inactive && b.BlockStates[ghosts[1].Y][ghosts[1].X] == boardmodel.Inactive
+		})
+		if b.BlockColors[ghosts[0].Y][ghosts[0].X] != boardmodel.Red || b.BlockColors[ghosts[1].Y][ghosts[1].X] != boardmodel.Blue {
+			t.Errorf("%s: the pair did not land on its ghosts at %v", test.name, ghosts)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package boardmodel
+
+// CheckScore checks the board for scores in rows columns and diagonals
+func (b *Board) CheckScore(direction string, originalBlock, nextBlock Pos) {
+
+	switch direction {
+	case "up":
+		// Not needed
+	case "down":
+		if nextBlock.Y > (b.Height - 1) {
+			return
+		}
+	case "left":
+		if nextBlock.X < 0 {
+			return
+		}
+	case "right":
+		if nextBlock.X > b.Width-1 {
+			return
+		}
+	case "up_left":
+		if nextBlock.X < 0 || nextBlock.Y < 0 {
+			return
+		}
+	case "up_right":
+		if nextBlock.X > b.Width-1 || nextBlock.Y < 0 {
+			return
+		}
+	case "down_left":
+		if nextBlock.X < 0 || nextBlock.Y > (b.Height-1) {
+			return
+		}
+	case "down_right":
+		if nextBlock.X > b.Width-1 || nextBlock.Y > (b.Height-1) {
+			return
+		}
+	default:
+	}
+
+	if b.Rules.Matches(b.BlockColors[originalBlock.Y][originalBlock.X], b.BlockColors[nextBlock.Y][nextBlock.X]) == true &&
+		b.BlockStates[nextBlock.Y][nextBlock.X] == Inactive {
+
+		b.BlocksForScore++
+		b.BlockStates[originalBlock.Y][originalBlock.X] = Exploding
+		b.BlockStates[nextBlock.Y][nextBlock.X] = Exploding
+
+		// A wildcard at the start of a line takes on the color of the first block after it
+		if b.BlockColors[originalBlock.Y][originalBlock.X].Wildcard() == true {
+			originalBlock = nextBlock
+		}
+
+		switch direction {
+		case "up":
+			// Not needed
+		case "down":
+			b.CheckScore("down", originalBlock, Pos{nextBlock.X, nextBlock.Y + 1})
+		case "left":
+			b.CheckScore("left", originalBlock, Pos{nextBlock.X - 1, nextBlock.Y})
+		case "right":
+			b.CheckScore("right", originalBlock, Pos{nextBlock.X + 1, nextBlock.Y})
+		case "up_left":
+			b.CheckScore("up_left", originalBlock, Pos{nextBlock.X - 1, nextBlock.Y - 1})
+		case "up_right":
+			b.CheckScore("up_right", originalBlock, Pos{nextBlock.X + 1, nextBlock.Y - 1})
+		case "down_left":
+			b.CheckScore("down_left", originalBlock, Pos{nextBlock.X - 1, nextBlock.Y + 1})
+		case "down_right":
+			b.CheckScore("down_right", originalBlock, Pos{nextBlock.X + 1, nextBlock.Y + 1})
+		default:
+		}
+	}
+}
+
+// HandleScoreBlocks contains the logic for what should happen to blocks after they are marked by the CheckScore functions - the marked line scores if it is at least Rules.MinMatch blocks long
+func (b *Board) HandleScoreBlocks() {
+	if b.BlocksForScore >= b.Rules.MinMatch-1 {
+		b.clearExploding()
+	}
+
+	// Blocks that were marked but did not make a score go back to being inactive
+	for n := range b.BlockStates {
+		for m := range b.BlockStates[n] {
+			if b.BlockStates[n][m] == Exploding {
+				b.BlockStates[n][m] = Inactive
+			}
+		}
+	}
+
+	b.BlocksForScore = 0
+}
+
+// clearExploding clears every block marked as exploding as a single match, along with the blocks of any special blocks among them
+func (b *Board) clearExploding() {
+	b.BlockScorePausing = true
+	b.LevelFallingTimer = 0
+	b.DeGrayValue--
+
+	b.setOffSpecials()
+
+	cleared := make([]ClearedBlock, 0)
+	for k := range b.BlockStates {
+		for l := range b.BlockStates[k] {
+			if b.BlockStates[k][l] == Exploding {
+				b.BlockStates[k][l] = Empty
+				cleared = append(cleared, ClearedBlock{Pos: Pos{l, k}, Color: b.BlockColors[k][l]})
+				b.BlocksCleared++
+			}
+		}
+	}
+	b.ClearedBlocks = append(b.ClearedBlocks, cleared)
+
+	// The points are added once every match of the step is known - see ScoreStep
+	b.StepGroups++
+	b.StepBlocks += len(cleared)
+}
+
+// ScoreStep adds the points for every match cleared during the current step
+// Each clear that follows the last one before the next block spawns is a link in a chain - the blocks of a step are worth BlockPointValue times the chain length times the number of matches cleared at once
+// Every special block set off is worth SpecialPointValue times the chain length on top
+// Only the flat BlockPointValue of each block counts towards the next level so that chains do not speed the game up
+func (b *Board) ScoreStep() {
+	if b.StepGroups == 0 {
+		return
+	}
+
+	b.Chain++
+	if b.Chain > b.MaxChain {
+		b.MaxChain = b.Chain
+	}
+	b.Combo = b.StepGroups
+
+	points := b.StepBlocks*b.BlockPointValue*b.Chain*b.Combo + b.StepSpecials*b.SpecialPointValue*b.Chain
+	if b.ScoreValue < b.MaxScoreValue {
+		if b.ScoreValue+points < b.MaxScoreValue {
+			b.ScoreValue += points
+			b.LevelScoreValue += b.StepBlocks * b.BlockPointValue
+		} else {
+			b.ScoreValue = b.MaxScoreValue
+		}
+	}
+
+	b.StepGroups = 0
+	b.StepBlocks = 0
+	b.StepSpecials = 0
+}
@@ -0,0 +1,60 @@
+package boardmodel_test
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"testing"
+)
+
+// placeCascade lays out a red row whose clearing lets the top blue block fall onto the two below it, for a chain of two
+func placeCascade(b *boardmodel.Board) {
+	place(b, boardmodel.Blue, boardmodel.Pos{0, 9}, boardmodel.Pos{0, 8}, boardmodel.Pos{0, 6})
+	place(b, boardmodel.Red, boardmodel.Pos{0, 7}, boardmodel.Pos{1, 7}, boardmodel.Pos{2, 7})
+	place(b, boardmodel.Green, boardmodel.Pos{1, 8}, boardmodel.Pos{2, 9})
+	place(b, boardmodel.Yellow, boardmodel.Pos{1, 9}, boardmodel.Pos{2, 8})
+}
+
+func TestMatchesClearedTogetherScoreAsACombo(t *testing.T) {
+	b := emptyBoard(1)
+	place(b, boardmodel.Red, boardmodel.Pos{0, 9}, boardmodel.Pos{1, 9}, boardmodel.Pos{2, 9})
+	place(b, boardmodel.Blue, boardmodel.Pos{4, 9}, boardmodel.Pos{4, 8}, boardmodel.Pos{4, 7})
+
+	b.Step()
+
+	// Six blocks in two matches on the first link of a chain
+	points := 6 * b.BlockPointValue * 1 * 2
+	if b.Combo != 2 || b.Chain != 1 {
+		t.Errorf("combo %d and chain %d, expected a combo of 2 on a chain of 1", b.Combo, b.Chain)
+	}
+	if b.ScoreValue != points {
+		t.Errorf("score is %d, expected %d", b.ScoreValue, points)
+	}
+	if b.LevelScoreValue != 6*b.BlockPointValue {
+		t.Errorf("level score is %d, expected the flat %d", b.LevelScoreValue, 6*b.BlockPointValue)
+	}
+}
+
+func TestCascadesScoreAsAChain(t *testing.T) {
+	b := emptyBoard(1)
+	placeCascade(b)
+
+	for f := 0; f < 400; f++ {
+		b.Step()
+	}
+
+	// Three blocks on the first link and three worth twice as much on the second
+	points := 3*b.BlockPointValue*1 + 3*b.BlockPointValue*2
+	if b.MaxChain != 2 {
+		t.Errorf("longest chain is %d, expected 2", b.MaxChain)
+	}
+	if b.ScoreValue != points {
+		t.Errorf("score is %d, expected %d", b.ScoreValue, points)
+	}
+	if b.LevelScoreValue != 6*b.BlockPointValue {
+		t.Errorf("level score is %d, expected the flat %d", b.LevelScoreValue, 6*b.BlockPointValue)
+	}
+	for j := 7; j < b.Height; j++ {
+		if b.BlockStates[j][0] != boardmodel.Empty {
+			t.Errorf("block at {0 %d} is left over, expected the blue column to clear", j)
+		}
+	}
+}
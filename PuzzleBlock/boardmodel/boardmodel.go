@@ -0,0 +1,308 @@
+package boardmodel
+
+import (
+	"math/rand"
+	"strconv"
+)
+
+// Pos is a struct that holds X/ Y positions for the board
+type Pos struct {
+	X, Y int
+}
+
+// BlockState denotes the states a block on the board can be in
+type BlockState int
+
+const (
+	// Empty block is a block that is not being drawn
+	Empty BlockState = iota
+	// Active block is controlled by the player
+	Active
+	// Inactive is a block that is being drawn, but which is not controlled by the player
+	Inactive
+	// Exploding is a block that is in the process of exploding
+	Exploding
+)
+
+// Color denotes the color of a block - the values match the sequences in the gem sprite sheet
+type Color int
+
+const (
+	// Red blocks
+	Red Color = iota
+	// Green blocks
+	Green
+	// Blue blocks
+	Blue
+	// Yellow blocks
+	Yellow
+	// Violet blocks
+	Violet
+	// White blocks
+	White
+	// Gray blocks never score and are only removed by the De-Gray counter
+	Gray
+	// Multi blocks take on the color of the block they land on
+	Multi
+	// Bomb blocks match any color but Gray and clear the blocks around them when they are matched
+	Bomb
+	// Line blocks match any color but Gray and clear their whole row when they are matched
+	Line
+	// Nuke blocks clear every block of the color they land on
+	Nuke
+)
+
+// ClearedBlock is a block that a match cleared off the board, with the color it had when it went - the cell it was in may be filled again by the time it is drawn
+type ClearedBlock struct {
+	Pos   Pos
+	Color Color
+}
+
+// Input is a single move applied to the board, stamped with the frame it was applied on and the player who made it
+type Input struct {
+	Frame  int    `json:"frame"`
+	Move   string `json:"move"`
+	Player int    `json:"player,omitempty"`
+}
+
+// Results holds the numbers that sum up a finished game - Completed is set if the game reached the end of a timed mode instead of filling up
+type Results struct {
+	Score         int
+	Level         int
+	BlocksCleared int
+	PlayTime      float64
+	Mode          Mode
+	Completed     bool
+}
+
+// Board holds the state of the play area and applies the rules of the game to it without drawing anything
+type Board struct {
+	Width, Height      int
+	BlockStates        [][]BlockState
+	BlockColors        [][]Color
+	Pieces             []Piece
+	PairMode           bool
+	Rules              Rules
+	Levels             []Level
+	Mode               Mode
+	ModeCompleted      bool
+	Puzzle             *Puzzle
+	PiecesUsed         int
+	PuzzleSolved       bool
+	Generator          PieceGenerator
+	Queue              []Color
+	HoldColor          Color
+	Holding            bool
+	StartLevel         int
+	LevelValue         int
+	MaxLevelValue      int
+	ScoreValue         int
+	MaxScoreValue      int
+	DeGrayValue        int
+	MaxDeGrayValue     int
+	BlockPointValue    int
+	SpecialPointValue  int
+	LevelScoreValue    int
+	MaxLevelScoreValue int
+	LevelFall          bool
+	LevelFallingTime   float64
+	LevelFallingTimer  float64
+	LevelPostFallTime  float64
+	LevelPostFallTimer float64
+	BlocksFalling      int
+	BlockFallingTime   float64
+	BlockFallingTimer  float64
+	BlocksFallingTime  float64
+	BlocksFallingTimer float64
+	RiseTimer          float64
+	GarbageIn          int
+	GarbageOut         int
+	GameOverTime       float64
+	GameOverTimer      float64
+	GameOverPausing    bool
+	GameOver           bool
+	BlockScorePausing  bool
+	BlocksForScore     int
+	BlocksCleared      int
+	StepGroups         int
+	StepBlocks         int
+	StepSpecials       int
+	Chain              int
+	MaxChain           int
+	Combo              int
+	ClearedBlocks      [][]ClearedBlock
+	SpecialsSetOff     []Color
+	Seed               int64
+	Rand               *rand.Rand
+	EffectsRand        *rand.Rand
+	Frame              int
+	FrameTimer         float64
+	Recording          bool
+	Inputs             []Input
+	PlayingBack        bool
+	PlaybackInputs     []Input
+	PlaybackIndex      int
+}
+
+// NewBoard is a board constructor - every random choice the board makes is drawn from a source seeded with 'seed'
+func NewBoard(width, height int, seed int64) *Board {
+
+	b := &Board{}
+
+	b.Width = width
+	b.Height = height
+
+	b.BlockStates = make([][]BlockState, height)
+	b.BlockColors = make([][]Color, height)
+	for j := range b.BlockStates {
+		b.BlockStates[j] = make([]BlockState, width)
+		b.BlockColors[j] = make([]Color, width)
+	}
+
+	b.MaxScoreValue = 9999999
+	b.MaxDeGrayValue = 10
+	b.BlockPointValue = 10
+	b.SpecialPointValue = 50
+
+	b.BlockFallingTime = 75
+	b.BlocksFallingTime = b.BlockFallingTime * float64(b.Height)
+
+	b.GameOverTime = 1000
+
+	b.Rules = DefaultRules()
+	b.Levels = DefaultLevels()
+	b.Mode = EndlessMode()
+	b.StartLevel = 1
+
+	b.SetPlayers(1)
+	b.SetSeed(seed)
+	b.Reset()
+
+	return b
+}
+
+// Reset empties the board and sets the level, score and timers back to their starting values
+func (b *Board) Reset() {
+	// Colors are cleared too, so that a board that is reset is the same as a new one
+	for j := range b.BlockStates {
+		for i := range b.BlockStates[j] {
+			b.BlockStates[j][i] = Empty
+			b.BlockColors[j][i] = Red
+		}
+	}
+
+	for k := range b.Pieces {
+		b.Pieces[k] = Piece{Pos: Pos{-1, -1}, Partner: Pos{-1, -1}}
+	}
+	b.HoldColor = Red
+	b.Holding = false
+	b.Puzzle = nil
+	b.PiecesUsed = 0
+	b.PuzzleSolved = false
+
+	// The queue is filled once the level is set so that it starts out with the colors of the starting level - a starting level past the end of the table starts on level 1
+	b.LevelValue = b.StartLevel
+	if b.LevelValue < 1 || b.LevelValue > len(b.Levels) {
+		b.LevelValue = 1
+	}
+	b.applyLevel()
+	b.FillQueue()
+
+	b.ScoreValue = 0
+	b.DeGrayValue = b.MaxDeGrayValue
+	b.LevelScoreValue = 0
+
+	b.LevelFall = false
+	b.LevelFallingTimer = 0
+	b.LevelPostFallTime = b.CurrentLevel().LockDelay
+	b.LevelPostFallTimer = 0
+
+	b.BlocksFalling = 0
+	b.BlockFallingTimer = 0
+	b.BlocksFallingTimer = 0
+
+	b.RiseTimer = 0
+	b.GarbageIn = 0
+	b.GarbageOut = 0
+
+	b.GameOverTimer = 0
+	b.GameOverPausing = false
+	b.GameOver = false
+	b.ModeCompleted = false
+	b.BlockScorePausing = false
+
+	b.BlocksForScore = 0
+	b.BlocksCleared = 0
+	b.StepGroups = 0
+	b.StepBlocks = 0
+	b.StepSpecials = 0
+	b.Chain = 0
+	b.MaxChain = 0
+	b.Combo = 0
+	b.ClearedBlocks = nil
+	b.SpecialsSetOff = nil
+
+	b.Frame = 0
+	b.FrameTimer = 0
+	b.Inputs = nil
+}
+
+// SetSeed replaces the random sources of the board with new ones seeded with 'seed' - the same seed and the same inputs always produce the same game
+// EffectsRand is kept apart from Rand so that drawing the board (explosions, sounds) never changes how the game plays out, and the generator has a source of its own so that the queue of upcoming blocks does not either
+func (b *Board) SetSeed(seed int64) {
+	b.Seed = seed
+	b.Rand = rand.New(rand.NewSource(seed))
+	b.EffectsRand = rand.New(rand.NewSource(seed + 1))
+	b.Generator = NewRandomGenerator(seed + 2)
+}
+
+// StartRecording starts a new game from 'seed' and logs every input applied to the board from then on
+func (b *Board) StartRecording(seed int64) {
+	b.SetSeed(seed)
+	b.Reset()
+	b.PlayingBack = false
+	b.Recording = true
+}
+
+// StartPlayback starts a new game from 'seed' and feeds 'inputs' back into the board on the frames they were recorded on
+func (b *Board) StartPlayback(seed int64, inputs []Input) {
+	b.SetSeed(seed)
+	b.Reset()
+	b.Recording = false
+	b.PlayingBack = true
+	b.PlaybackInputs = inputs
+	b.PlaybackIndex = 0
+}
+
+// PlayTime returns the number of milliseconds the current game has been running for
+func (b *Board) PlayTime() float64 {
+	return float64(b.Frame) * FrameTime
+}
+
+// Results returns the score, level, blocks cleared, play time and mode of the current game
+func (b *Board) Results() Results {
+	return Results{Score: b.ScoreValue, Level: b.LevelValue, BlocksCleared: b.BlocksCleared, PlayTime: b.PlayTime(), Mode: b.Mode, Completed: b.ModeCompleted}
+}
+
+// FormatTime turns a number of milliseconds into minutes and seconds, e.g. 2:05
+func FormatTime(ms float64) string {
+	seconds := int(ms / 1000)
+	if seconds%60 < 10 {
+		return strconv.Itoa(seconds/60) + ":0" + strconv.Itoa(seconds%60)
+	}
+	return strconv.Itoa(seconds/60) + ":" + strconv.Itoa(seconds%60)
+}
+
+// FormatTimePrecise turns a number of milliseconds into minutes, seconds and hundredths of a second, e.g. 2:05.40
+func FormatTimePrecise(ms float64) string {
+	hundredths := int(ms/10) % 100
+	if hundredths < 10 {
+		return FormatTime(ms) + ".0" + strconv.Itoa(hundredths)
+	}
+	return FormatTime(ms) + "." + strconv.Itoa(hundredths)
+}
+
+// IsFilled returns true if the block at x, y holds a block of any kind
+func (b *Board) IsFilled(x, y int) bool {
+	return b.BlockStates[y][x] != Empty
+}
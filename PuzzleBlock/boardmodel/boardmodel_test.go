@@ -0,0 +1,150 @@
+package boardmodel_test
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"reflect"
+	"testing"
+)
+
+// steer presses the key that moves the active block of 'b' over a column it matches the top of, or over the lowest column if none match, and pushes it down once it is there
+func steer(b *boardmodel.Board) {
+	a := b.Pieces[0].Pos
+	if a.X == -1 || a.Y == -1 {
+		return
+	}
+
+	target, lowest, lowestTop := -1, -1, -1
+	for i := 0; i < b.Width; i++ {
+		top := a.Y + 1
+		for top < b.Height && b.BlockStates[top][i] == boardmodel.Empty {
+			top++
+		}
+		if target == -1 && top < b.Height && b.BlockColors[top][i] == b.BlockColors[a.Y][a.X] {
+			target = i
+		}
+		if top > lowestTop {
+			lowest, lowestTop = i, top
+		}
+	}
+	if target == -1 {
+		target = lowest
+	}
+
+	switch {
+	case target < a.X:
+		b.MoveActiveBlock("left")
+	case target > a.X:
+		b.MoveActiveBlock("right")
+	default:
+		b.MoveActiveBlock("down")
+	}
+}
+
+// play plays a game of 'frames' steps of 5 milliseconds from 'seed', steering the active block every 'every' steps
+func play(seed int64, every, frames int) *boardmodel.Board {
+	b := boardmodel.NewBoard(5, 10, seed)
+	for f := 0; f < frames && b.GameOver == false; f++ {
+		if f%every == 0 {
+			steer(b)
+		}
+		b.Update(5)
+	}
+	return b
+}
+
+// emptyBoard returns a board with nothing on it that deals blocks from 'seed'
+func emptyBoard(seed int64) *boardmodel.Board {
+	return boardmodel.NewBoard(5, 10, seed)
+}
+
+// place puts settled blocks of 'color' at 'positions'
+func place(b *boardmodel.Board, color boardmodel.Color, positions ...boardmodel.Pos) {
+	for _, p := range positions {
+		b.BlockStates[p.Y][p.X] = boardmodel.Inactive
+		b.BlockColors[p.Y][p.X] = color
+	}
+}
+
+func TestSameSeedGivesSameBoard(t *testing.T) {
+	a := play(1, 7, 20000)
+	if a.ScoreValue == 0 {
+		t.Fatal("the game scored no points, so it tests nothing")
+	}
+
+	b := play(1, 7, 20000)
+	if reflect.DeepEqual(a, b) == false {
+		t.Errorf("boards played from the same seed differ - scores %d and %d", a.ScoreValue, b.ScoreValue)
+	}
+
+	c := play(2, 7, 20000)
+	if reflect.DeepEqual(a.BlockColors, c.BlockColors) == true {
+		t.Errorf("boards played from different seeds dealt the same blocks")
+	}
+}
+
+func TestLineClears(t *testing.T) {
+	b := emptyBoard(1)
+	line := []boardmodel.Pos{{0, 9}, {1, 9}, {2, 9}}
+	place(b, boardmodel.Red, line...)
+	place(b, boardmodel.Blue, boardmodel.Pos{3, 9})
+	degray := b.DeGrayValue
+
+	b.Update(5)
+
+	for _, p := range line {
+		if b.BlockStates[p.Y][p.X] != boardmodel.Empty {
+			t.Errorf("block at %v of the line of three is not cleared", p)
+		}
+	}
+	if b.BlockStates[9][3] != boardmodel.Inactive || b.BlockColors[9][3] != boardmodel.Blue {
+		t.Errorf("block at {3 9} next to the line is cleared")
+	}
+	if len(b.ClearedBlocks) != 1 || len(b.ClearedBlocks[0]) != len(line) {
+		t.Errorf("cleared %v, expected a single match of %d blocks", b.ClearedBlocks, len(line))
+	}
+	if b.ScoreValue != len(line)*b.BlockPointValue {
+		t.Errorf("score is %d, expected %d", b.ScoreValue, len(line)*b.BlockPointValue)
+	}
+	if b.DeGrayValue != degray-1 {
+		t.Errorf("De-Gray counter is %d, expected %d", b.DeGrayValue, degray-1)
+	}
+}
+
+func TestShortLinesAndGrayDoNotClear(t *testing.T) {
+	b := emptyBoard(1)
+	place(b, boardmodel.Red, boardmodel.Pos{0, 9}, boardmodel.Pos{1, 9})
+	place(b, boardmodel.Gray, boardmodel.Pos{2, 9}, boardmodel.Pos{3, 9}, boardmodel.Pos{4, 9})
+
+	b.Update(5)
+
+	for i := 0; i < b.Width; i++ {
+		if b.BlockStates[9][i] != boardmodel.Inactive {
+			t.Errorf("block at {%d 9} is cleared", i)
+		}
+	}
+	if b.ScoreValue != 0 || len(b.ClearedBlocks) != 0 {
+		t.Errorf("scored %d points for %v, expected nothing", b.ScoreValue, b.ClearedBlocks)
+	}
+}
+
+func TestDeGray(t *testing.T) {
+	b := emptyBoard(1)
+	place(b, boardmodel.Red, boardmodel.Pos{0, 9}, boardmodel.Pos{1, 9}, boardmodel.Pos{2, 9})
+	grays := []boardmodel.Pos{{4, 9}, {4, 8}, {4, 7}}
+	place(b, boardmodel.Gray, grays...)
+	b.DeGrayValue = 1
+
+	b.Update(5)
+
+	for _, p := range grays {
+		if b.BlockStates[p.Y][p.X] != boardmodel.Inactive {
+			t.Errorf("block at %v is cleared, expected it to stay on the board", p)
+		}
+		if b.BlockColors[p.Y][p.X] >= boardmodel.Gray {
+			t.Errorf("block at %v is %d after the De-Gray counter ran out, expected a plain color", p, b.BlockColors[p.Y][p.X])
+		}
+	}
+	if b.DeGrayValue != b.MaxDeGrayValue {
+		t.Errorf("De-Gray counter is %d, expected it back at %d", b.DeGrayValue, b.MaxDeGrayValue)
+	}
+}
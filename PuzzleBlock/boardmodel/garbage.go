@@ -0,0 +1,53 @@
+package boardmodel
+
+// canRise returns true if a row of garbage can be pushed up without losing anything - the top row has to be empty, which also keeps the active blocks below it, and nothing can be in the middle of being cleared
+func (b *Board) canRise() bool {
+	if b.BlockScorePausing == true {
+		return false
+	}
+	for i := range b.BlockStates[0] {
+		if b.BlockStates[0][i] != Empty {
+			return false
+		}
+	}
+	return true
+}
+
+// RiseGarbage moves every row of the board up by one, taking the active blocks with them, and fills the bottom row with Gray blocks
+func (b *Board) RiseGarbage() {
+	for j := 0; j < b.Height-1; j++ {
+		copy(b.BlockStates[j], b.BlockStates[j+1])
+		copy(b.BlockColors[j], b.BlockColors[j+1])
+	}
+
+	for i := range b.BlockStates[b.Height-1] {
+		b.BlockStates[b.Height-1][i] = Inactive
+		b.BlockColors[b.Height-1][i] = Gray
+	}
+
+	for k := range b.Pieces {
+		if b.HasPiece(k) == true {
+			b.Pieces[k].Pos.Y--
+		}
+		if b.HasPartner(k) == true {
+			b.Pieces[k].Partner.Y--
+		}
+	}
+}
+
+// UpdateRise pushes a row of garbage up once every rise interval of the current level in modes that have rising garbage - a row that is due waits until there is room for it
+func (b *Board) UpdateRise(time float64) {
+	interval := b.CurrentLevel().RiseInterval
+	if b.Mode.RisingGarbage == false || interval <= 0 {
+		return
+	}
+
+	if b.RiseTimer >= interval {
+		if b.canRise() == true {
+			b.RiseGarbage()
+			b.RiseTimer = 0
+		}
+	} else {
+		b.RiseTimer += time
+	}
+}
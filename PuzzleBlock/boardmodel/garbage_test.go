@@ -0,0 +1,70 @@
+package boardmodel_test
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"testing"
+)
+
+func TestRiseGarbagePushesARowOfGrayUp(t *testing.T) {
+	b := emptyBoard(1)
+	place(b, boardmodel.Red, boardmodel.Pos{0, 9})
+	b.Pieces[0].Pos = boardmodel.Pos{2, 3}
+	b.BlockStates[3][2] = boardmodel.Active
+	b.BlockColors[3][2] = boardmodel.Blue
+
+	b.RiseGarbage()
+
+	if b.BlockStates[8][0] != boardmodel.Inactive || b.BlockColors[8][0] != boardmodel.Red {
+		t.Errorf("the red block did not move up a row")
+	}
+	for i := 0; i < b.Width; i++ {
+		if b.BlockStates[9][i] != boardmodel.Inactive || b.BlockColors[9][i] != boardmodel.Gray {
+			t.Errorf("block at {%d 9} is %d of color %d, expected settled Gray", i, b.BlockStates[9][i], b.BlockColors[9][i])
+		}
+	}
+	if b.Pieces[0].Pos != (boardmodel.Pos{2, 2}) || b.BlockStates[2][2] != boardmodel.Active || b.BlockColors[2][2] != boardmodel.Blue {
+		t.Errorf("the active block is at %v, expected it pushed up to {2 2}", b.Pieces[0].Pos)
+	}
+}
+
+func TestGarbageRisesOnlyInRisingModes(t *testing.T) {
+	for _, mode := range []boardmodel.Mode{boardmodel.EndlessMode(), boardmodel.RisingMode()} {
+		b := emptyBoard(1)
+		b.Mode = mode
+		for f := 0; f < 5000; f++ {
+			b.Step()
+		}
+
+		rose := b.BlockStates[9][0] == boardmodel.Inactive && b.BlockColors[9][0] == boardmodel.Gray && b.BlockColors[9][1] == boardmodel.Gray
+		if rose != mode.RisingGarbage {
+			t.Errorf("%s: garbage rose %v in %d frames", mode.Name, rose, b.Frame)
+		}
+	}
+}
+
+func TestRisingGarbageTopsOut(t *testing.T) {
+	b := emptyBoard(1)
+	b.Mode = boardmodel.RisingMode()
+	for l := range b.Levels {
+		b.Levels[l].RiseInterval = 100
+	}
+
+	for f := 0; f < 10000 && b.GameOver == false; f++ {
+		b.Step()
+	}
+
+	if b.GameOver == false {
+		t.Fatalf("rows rising every 100 milliseconds did not end the game in %d frames", b.Frame)
+	}
+	full := false
+	for i := 0; i < b.Width; i++ {
+		column := true
+		for j := 0; j < b.Height; j++ {
+			column = column && b.BlockStates[j][i] == boardmodel.Inactive
+		}
+		full = full || column
+	}
+	if full == false {
+		t.Errorf("the game ended without a full column")
+	}
+}
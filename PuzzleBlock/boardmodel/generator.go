@@ -0,0 +1,97 @@
+package boardmodel
+
+import "math/rand"
+
+// MaxQueueLength is the number of upcoming blocks the board knows about ahead of time
+const MaxQueueLength = 5
+
+// PieceGenerator decides the color of every block before it joins the queue of upcoming blocks
+type PieceGenerator interface {
+	Next() Color
+}
+
+// RandomGenerator picks Gray, Multi and special blocks with the chance the current level gives them, and every other color the level uses with the same chance
+type RandomGenerator struct {
+	Rand  *rand.Rand
+	Level Level
+}
+
+// NewRandomGenerator is a random generator constructor - it deals out the colors of the first default level until it is given another
+func NewRandomGenerator(seed int64) *RandomGenerator {
+	return &RandomGenerator{Rand: rand.New(rand.NewSource(seed)), Level: DefaultLevels()[0]}
+}
+
+// SetLevel changes the mix of colors to that of 'level'
+func (r *RandomGenerator) SetLevel(level Level) {
+	r.Level = level
+}
+
+// Next returns the color of the next block
+func (r *RandomGenerator) Next() Color {
+	chance := r.Rand.Float64()
+	if chance < r.Level.GrayChance {
+		return Gray
+	}
+	if chance < r.Level.GrayChance+r.Level.MultiChance {
+		return Multi
+	}
+	if chance < r.Level.GrayChance+r.Level.MultiChance+r.Level.BombChance {
+		return Bomb
+	}
+	if chance < r.Level.GrayChance+r.Level.MultiChance+r.Level.BombChance+r.Level.LineChance {
+		return Line
+	}
+	if chance < r.Level.SpecialChance() {
+		return Nuke
+	}
+	return Color(r.Rand.Intn(r.Level.Colors))
+}
+
+// SequenceGenerator deals out a fixed list of colors in order - once the list has run out it deals Gray blocks, which are never spawned as long as the board keeps count of the pieces it has left
+type SequenceGenerator struct {
+	Colors []Color
+	Index  int
+}
+
+// NewSequenceGenerator is a sequence generator constructor
+func NewSequenceGenerator(colors []Color) *SequenceGenerator {
+	return &SequenceGenerator{Colors: colors, Index: 0}
+}
+
+// Next returns the color of the next block
+func (s *SequenceGenerator) Next() Color {
+	if s.Index >= len(s.Colors) {
+		return Gray
+	}
+	s.Index++
+	return s.Colors[s.Index-1]
+}
+
+// SetGenerator replaces the generator of the board and refills the queue of upcoming blocks from it
+func (b *Board) SetGenerator(generator PieceGenerator) {
+	b.Generator = generator
+	b.applyLevel()
+	b.FillQueue()
+}
+
+// FillQueue throws away the queue of upcoming blocks and draws a new one from the generator
+func (b *Board) FillQueue() {
+	b.Queue = make([]Color, MaxQueueLength)
+	for i := range b.Queue {
+		b.Queue[i] = b.Generator.Next()
+	}
+}
+
+// NextColor returns the color of the block that will be spawned next
+func (b *Board) NextColor() Color {
+	return b.Queue[0]
+}
+
+// popQueue takes the next color off the front of the queue and draws a new one onto the back
+func (b *Board) popQueue() Color {
+	next := b.Queue[0]
+	copy(b.Queue, b.Queue[1:])
+	b.Queue[len(b.Queue)-1] = b.Generator.Next()
+	b.PiecesUsed++
+	return next
+}
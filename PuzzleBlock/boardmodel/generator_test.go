@@ -0,0 +1,78 @@
+package boardmodel_test
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"reflect"
+	"testing"
+)
+
+// cycleGenerator deals its colors over and over in the same order
+type cycleGenerator struct {
+	colors []boardmodel.Color
+	next   int
+}
+
+// Next returns the color of the next block
+func (c *cycleGenerator) Next() boardmodel.Color {
+	color := c.colors[c.next%len(c.colors)]
+	c.next++
+	return color
+}
+
+// dropActive pushes the active block of 'b' down until it lands and the next one comes in at the top
+func dropActive(t *testing.T, b *boardmodel.Board) {
+	moved := false
+	stepUntil(t, b, 10000, func() bool {
+		if moved == true && b.Pieces[0].Pos.Y == 0 {
+			return true
+		}
+		b.MoveActiveBlock("down")
+		moved = moved || b.Pieces[0].Pos.Y > 0
+		return false
+	})
+}
+
+func TestQueueDealsInOrder(t *testing.T) {
+	b := emptyBoard(1)
+	colors := []boardmodel.Color{boardmodel.Red, boardmodel.Green, boardmodel.Blue, boardmodel.Yellow, boardmodel.Violet, boardmodel.Gray, boardmodel.Multi}
+	b.SetGenerator(&cycleGenerator{colors: colors})
+
+	expected := []boardmodel.Color{boardmodel.Red, boardmodel.Green, boardmodel.Blue, boardmodel.Yellow, boardmodel.Violet}
+	if reflect.DeepEqual(b.Queue, expected) == false {
+		t.Fatalf("queue is %v, expected %v", b.Queue, expected)
+	}
+
+	// Blocks spawn from the front of the queue and new ones join at the back
+	b.Step()
+	for k := 0; k < 3; k++ {
+		if activeColor(b) != colors[k] {
+			t.Errorf("block %d is %d, expected %d", k+1, activeColor(b), colors[k])
+		}
+		expected = append(expected[1:], colors[(k+boardmodel.MaxQueueLength)%len(colors)])
+		if reflect.DeepEqual(b.Queue, expected) == false {
+			t.Errorf("queue after block %d is %v, expected %v", k+1, b.Queue, expected)
+		}
+		dropActive(t, b)
+	}
+}
+
+func TestGeneratorIsDeterministicBySeed(t *testing.T) {
+	deal := func(seed int64) []boardmodel.Color {
+		g := boardmodel.NewRandomGenerator(seed)
+		colors := make([]boardmodel.Color, 100)
+		for k := range colors {
+			colors[k] = g.Next()
+		}
+		return colors
+	}
+
+	if reflect.DeepEqual(deal(5), deal(5)) == false {
+		t.Errorf("generators seeded with 5 dealt different colors")
+	}
+	if reflect.DeepEqual(deal(5), deal(6)) == true {
+		t.Errorf("generators seeded with 5 and 6 dealt the same colors")
+	}
+	if reflect.DeepEqual(emptyBoard(5).Queue, emptyBoard(5).Queue) == false {
+		t.Errorf("boards seeded with 5 start with different queues")
+	}
+}
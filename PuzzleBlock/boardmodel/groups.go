@@ -0,0 +1,60 @@
+package boardmodel
+
+// FindGroup returns every settled block that is orthogonally connected to 'start' through blocks that match under 'rules', 'start' included
+// The group takes the color of the first block in it that is not a wildcard, so a wildcard never joins two different colors together
+// Nil is returned if there is no settled block at 'start'
+func FindGroup(states [][]BlockState, colors [][]Color, start Pos, rules Rules) []Pos {
+	height := len(states)
+	if start.Y < 0 || start.Y >= height || start.X < 0 || start.X >= len(states[start.Y]) ||
+		states[start.Y][start.X] != Inactive {
+		return nil
+	}
+
+	visited := make([][]bool, height)
+	for j := range visited {
+		visited[j] = make([]bool, len(states[j]))
+	}
+
+	groupColor := colors[start.Y][start.X]
+	group := []Pos{start}
+	visited[start.Y][start.X] = true
+
+	for k := 0; k < len(group); k++ {
+		p := group[k]
+		for _, n := range []Pos{{p.X, p.Y + 1}, {p.X - 1, p.Y}, {p.X + 1, p.Y}, {p.X, p.Y - 1}} {
+			if n.Y < 0 || n.Y >= height || n.X < 0 || n.X >= len(states[n.Y]) ||
+				visited[n.Y][n.X] == true || states[n.Y][n.X] != Inactive {
+				continue
+			}
+			if rules.Matches(groupColor, colors[n.Y][n.X]) == false {
+				continue
+			}
+
+			visited[n.Y][n.X] = true
+			group = append(group, n)
+			if groupColor.Wildcard() == true {
+				groupColor = colors[n.Y][n.X]
+			}
+		}
+	}
+
+	return group
+}
+
+// GroupAt returns the group of settled blocks connected to 'p' on the board
+func (b *Board) GroupAt(p Pos) []Pos {
+	return FindGroup(b.BlockStates, b.BlockColors, p, b.Rules)
+}
+
+// CheckGroupScore marks the group connected to 'p' for clearing if it holds at least Rules.MinMatch blocks and hands it on to HandleScoreBlocks
+func (b *Board) CheckGroupScore(p Pos) {
+	group := b.GroupAt(p)
+	if len(group) >= b.Rules.MinMatch {
+		for _, g := range group {
+			b.BlockStates[g.Y][g.X] = Exploding
+		}
+		b.BlocksForScore = len(group) - 1
+	}
+
+	b.HandleScoreBlocks()
+}
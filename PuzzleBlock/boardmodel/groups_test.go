@@ -0,0 +1,132 @@
+package boardmodel_test
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"testing"
+)
+
+// samePositions returns true if 'a' and 'c' hold the same positions in any order
+func samePositions(a, c []boardmodel.Pos) bool {
+	if len(a) != len(c) {
+		return false
+	}
+	seen := map[boardmodel.Pos]int{}
+	for _, p := range a {
+		seen[p]++
+	}
+	for _, p := range c {
+		seen[p]--
+		if seen[p] < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFindGroup(t *testing.T) {
+	const (
+		R = boardmodel.Red
+		G = boardmodel.Green
+		M = boardmodel.Multi
+	)
+	rules := boardmodel.DefaultRules()
+	rules.Mode = boardmodel.GroupMatches
+	wild := rules
+	wild.WildcardMatches = true
+
+	tests := []struct {
+		name   string
+		rules  boardmodel.Rules
+		blocks []block
+		start  boardmodel.Pos
+		want   []boardmodel.Pos
+	}{
+		{
+			"a bent group of one color",
+			rules,
+			[]block{{R, boardmodel.Pos{0, 9}}, {R, boardmodel.Pos{1, 9}}, {R, boardmodel.Pos{1, 8}}, {R, boardmodel.Pos{2, 8}}, {G, boardmodel.Pos{2, 9}}},
+			boardmodel.Pos{0, 9},
+			[]boardmodel.Pos{{0, 9}, {1, 9}, {1, 8}, {2, 8}},
+		},
+		{
+			"blocks touching only at a corner",
+			rules,
+			[]block{{R, boardmodel.Pos{0, 9}}, {R, boardmodel.Pos{1, 8}}, {R, boardmodel.Pos{2, 7}}, {G, boardmodel.Pos{1, 9}}},
+			boardmodel.Pos{0, 9},
+			[]boardmodel.Pos{{0, 9}},
+		},
+		{
+			"a wildcard at the start takes the color of its first neighbor",
+			wild,
+			[]block{{M, boardmodel.Pos{1, 8}}, {R, boardmodel.Pos{1, 9}}, {R, boardmodel.Pos{2, 9}}, {G, boardmodel.Pos{0, 8}}, {G, boardmodel.Pos{0, 9}}},
+			boardmodel.Pos{1, 8},
+			[]boardmodel.Pos{{1, 8}, {1, 9}, {2, 9}},
+		},
+		{
+			"a wildcard between two colors",
+			wild,
+			[]block{{R, boardmodel.Pos{0, 9}}, {M, boardmodel.Pos{1, 9}}, {G, boardmodel.Pos{2, 9}}, {G, boardmodel.Pos{3, 9}}},
+			boardmodel.Pos{0, 9},
+			[]boardmodel.Pos{{0, 9}, {1, 9}},
+		},
+		{
+			"a wildcard with wildcards off",
+			rules,
+			[]block{{R, boardmodel.Pos{0, 9}}, {M, boardmodel.Pos{1, 9}}, {R, boardmodel.Pos{2, 9}}},
+			boardmodel.Pos{0, 9},
+			[]boardmodel.Pos{{0, 9}},
+		},
+		{"a start to the left of the board", rules, row(9, R, R, R), boardmodel.Pos{-1, 9}, nil},
+		{"a start to the right of the board", rules, row(9, R, R, R), boardmodel.Pos{5, 9}, nil},
+		{"a start below the board", rules, row(9, R, R, R), boardmodel.Pos{0, 10}, nil},
+		{"a start on an empty cell", rules, row(9, R, R, R), boardmodel.Pos{0, 8}, nil},
+	}
+
+	for _, test := range tests {
+		b := emptyBoard(1)
+		for _, bl := range test.blocks {
+			place(b, bl.color, bl.pos)
+		}
+
+		group := boardmodel.FindGroup(b.BlockStates, b.BlockColors, test.start, test.rules)
+		if test.want == nil && group != nil {
+			t.Errorf("%s: found %v, expected nil", test.name, group)
+		} else if samePositions(group, test.want) == false {
+			t.Errorf("%s: found %v, expected %v", test.name, group, test.want)
+		}
+	}
+}
+
+func TestFindGroupSkipsActiveBlocks(t *testing.T) {
+	b := emptyBoard(1)
+	place(b, boardmodel.Red, boardmodel.Pos{0, 9}, boardmodel.Pos{1, 9})
+	b.BlockStates[8][0] = boardmodel.Active
+	b.BlockColors[8][0] = boardmodel.Red
+
+	if group := boardmodel.FindGroup(b.BlockStates, b.BlockColors, boardmodel.Pos{0, 8}, b.Rules); group != nil {
+		t.Errorf("found %v from an active block, expected nil", group)
+	}
+	if group := boardmodel.FindGroup(b.BlockStates, b.BlockColors, boardmodel.Pos{0, 9}, b.Rules); samePositions(group, []boardmodel.Pos{{0, 9}, {1, 9}}) == false {
+		t.Errorf("found %v, expected the two settled blocks", group)
+	}
+}
+
+func TestCheckGroupScoreNeedsMinMatch(t *testing.T) {
+	square := []boardmodel.Pos{{0, 9}, {1, 9}, {0, 8}, {1, 8}}
+
+	for _, minMatch := range []int{3, 4, 5} {
+		b := emptyBoard(1)
+		b.Rules.Mode = boardmodel.GroupMatches
+		b.Rules.MinMatch = minMatch
+		place(b, boardmodel.Red, square...)
+
+		b.CheckGroupScore(boardmodel.Pos{0, 9})
+
+		clears := minMatch <= len(square)
+		for _, p := range square {
+			if (b.BlockStates[p.Y][p.X] == boardmodel.Empty) != clears {
+				t.Errorf("min_match %d: block at %v is %d, expected the group of %d to clear: %v", minMatch, p, b.BlockStates[p.Y][p.X], len(square), clears)
+			}
+		}
+	}
+}
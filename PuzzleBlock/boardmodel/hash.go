@@ -0,0 +1,36 @@
+package boardmodel
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+)
+
+// Hash returns a checksum of everything that decides how the rest of the game plays out - two boards that have played the same game from the same seed always have the same hash
+func (b *Board) Hash() uint64 {
+	h := fnv.New64a()
+
+	values := []int64{
+		int64(b.Frame),
+		int64(b.HoldColor), int64(b.LevelValue), int64(b.ScoreValue), int64(b.DeGrayValue),
+		int64(b.Chain), int64(b.GarbageIn), int64(b.GarbageOut),
+	}
+	for _, p := range b.Pieces {
+		values = append(values, int64(p.Pos.X), int64(p.Pos.Y), int64(p.Partner.X), int64(p.Partner.Y))
+	}
+	for j := range b.BlockStates {
+		for i := range b.BlockStates[j] {
+			values = append(values, int64(b.BlockStates[j][i]), int64(b.BlockColors[j][i]))
+		}
+	}
+	for _, c := range b.Queue {
+		values = append(values, int64(c))
+	}
+
+	buf := make([]byte, 8)
+	for _, v := range values {
+		binary.LittleEndian.PutUint64(buf, uint64(v))
+		h.Write(buf)
+	}
+
+	return h.Sum64()
+}
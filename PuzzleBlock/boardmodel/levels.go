@@ -0,0 +1,130 @@
+package boardmodel
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strconv"
+)
+
+// Level holds the speed and the mix of block colors of a single level of the game
+type Level struct {
+	FallInterval float64 `json:"fall_interval"`
+	LockDelay    float64 `json:"lock_delay"`
+	Points       int     `json:"points"`
+	Colors       int     `json:"colors"`
+	GrayChance   float64 `json:"gray_chance"`
+	MultiChance  float64 `json:"multi_chance"`
+	BombChance   float64 `json:"bomb_chance"`
+	LineChance   float64 `json:"line_chance"`
+	NukeChance   float64 `json:"nuke_chance"`
+	RiseInterval float64 `json:"rise_interval"`
+}
+
+// SpecialChance returns the chance of a block of the level being a Gray, Multi or special block rather than a plain color
+func (l Level) SpecialChance() float64 {
+	return l.GrayChance + l.MultiChance + l.BombChance + l.LineChance + l.NukeChance
+}
+
+// LevelledGenerator is a piece generator whose mix of colors changes with the level of the game
+type LevelledGenerator interface {
+	PieceGenerator
+	SetLevel(level Level)
+}
+
+// DefaultLevels returns the levels of the standard game - ten levels of 100 points each that fall faster and give less time after a push down the higher they go, all using five colors with the same chance of a Gray or Multi block as of any one color - garbage rises every 20 seconds on level 1, 1.5 seconds sooner on each level after it
+// No special gems are dealt - the setup of a game switches them on
+func DefaultLevels() []Level {
+	levels := make([]Level, 10)
+	for l := range levels {
+		levels[l] = Level{
+			FallInterval: 1000 / float64(l+1),
+			LockDelay:    float64(10*(10-(l+1))) + 1,
+			Points:       100,
+			Colors:       5,
+			GrayChance:   1.0 / 7.0,
+			MultiChance:  1.0 / 7.0,
+			BombChance:   0,
+			LineChance:   0,
+			NukeChance:   0,
+			RiseInterval: 20000 - 1500*float64(l),
+		}
+	}
+	return levels
+}
+
+// LoadLevels reads a table of levels from a JSON file - the first level in the file is level 1
+func LoadLevels(path string) ([]Level, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return DefaultLevels(), err
+	}
+
+	var levels []Level
+	err = json.Unmarshal(data, &levels)
+	if err != nil {
+		return DefaultLevels(), err
+	}
+
+	err = ValidateLevels(levels)
+	if err != nil {
+		return DefaultLevels(), err
+	}
+
+	return levels, nil
+}
+
+// ValidateLevels returns an error if a table of levels can not be played with
+func ValidateLevels(levels []Level) error {
+	if len(levels) == 0 {
+		return errors.New("levels: the table needs at least one level")
+	}
+
+	for l, level := range levels {
+		name := "levels: level " + strconv.Itoa(l+1)
+		if level.FallInterval <= 0 {
+			return errors.New(name + " needs a fall_interval above 0")
+		}
+		if level.LockDelay < 0 {
+			return errors.New(name + " can not have a negative lock_delay")
+		}
+		if level.Points <= 0 && l < len(levels)-1 {
+			return errors.New(name + " needs more than 0 points to reach the next level")
+		}
+		if level.Colors < 1 || level.Colors > int(Gray) {
+			return errors.New(name + " needs between 1 and " + strconv.Itoa(int(Gray)) + " colors")
+		}
+		if level.GrayChance < 0 || level.MultiChance < 0 || level.BombChance < 0 || level.LineChance < 0 || level.NukeChance < 0 || level.SpecialChance() > 1 {
+			return errors.New(name + " needs a gray_chance, multi_chance, bomb_chance, line_chance and nuke_chance of 0 or more that add up to 1 at most")
+		}
+		if level.RiseInterval < 0 {
+			return errors.New(name + " can not have a negative rise_interval")
+		}
+	}
+
+	return nil
+}
+
+// CurrentLevel returns the settings of the level the game is on
+func (b *Board) CurrentLevel() Level {
+	return b.Levels[b.LevelValue-1]
+}
+
+// applyLevel sets the speed, points and color mix of the board to those of the current level
+func (b *Board) applyLevel() {
+	level := b.CurrentLevel()
+
+	b.MaxLevelValue = len(b.Levels)
+	b.MaxLevelScoreValue = level.Points
+	b.LevelFallingTime = level.FallInterval
+
+	if g, ok := b.Generator.(LevelledGenerator); ok == true {
+		g.SetLevel(level)
+	}
+}
+
+// isLevelColor returns true if 'c' is a color that the current level deals out - Gray and Multi only are if the level gives them a chance
+func (b *Board) isLevelColor(c Color) bool {
+	level := b.CurrentLevel()
+	return int(c) < level.Colors || (c == Gray && level.GrayChance > 0) || (c == Multi && level.MultiChance > 0)
+}
@@ -0,0 +1,81 @@
+package boardmodel
+
+import "strconv"
+
+// Mode holds the limits of a way of playing the game - a mode without limits goes on until a column fills up
+// Modes with a TimeLimit are won by scoring as much as possible before the time runs out, modes with a BlockGoal by clearing that many blocks as fast as possible
+// In modes with RisingGarbage a row of Gray blocks pushes up from the bottom once every rise interval of the current level
+type Mode struct {
+	Name          string  `json:"name"`
+	TimeLimit     float64 `json:"time_limit"`
+	BlockGoal     int     `json:"block_goal"`
+	RisingGarbage bool    `json:"rising_garbage"`
+}
+
+// EndlessMode returns the mode of the standard game
+func EndlessMode() Mode {
+	return Mode{Name: "Endless"}
+}
+
+// UltraMode returns a mode that gives 'minutes' minutes to score as much as possible
+func UltraMode(minutes int) Mode {
+	return Mode{Name: "Ultra " + strconv.Itoa(minutes) + " Min", TimeLimit: float64(minutes) * 60 * 1000}
+}
+
+// TimeAttackMode returns a mode that is over once 'blocks' blocks have been cleared
+func TimeAttackMode(blocks int) Mode {
+	return Mode{Name: "Time Attack " + strconv.Itoa(blocks), BlockGoal: blocks}
+}
+
+// RisingMode returns an endless mode in which rows of Gray blocks keep pushing up from the bottom
+func RisingMode() Mode {
+	return Mode{Name: "Rising", RisingGarbage: true}
+}
+
+// Modes returns every mode that can be picked from the menu, in the order they are listed
+func Modes() []Mode {
+	return []Mode{
+		EndlessMode(),
+		RisingMode(),
+		UltraMode(2),
+		UltraMode(3),
+		UltraMode(5),
+		TimeAttackMode(50),
+		TimeAttackMode(100),
+	}
+}
+
+// RankedByTime returns true if games of the mode are ranked by how fast they are finished instead of by score
+func (m Mode) RankedByTime() bool {
+	return m.BlockGoal > 0
+}
+
+// TimeLeft returns the number of milliseconds left before the time limit of the mode runs out
+func (b *Board) TimeLeft() float64 {
+	if b.PlayTime() >= b.Mode.TimeLimit {
+		return 0
+	}
+	return b.Mode.TimeLimit - b.PlayTime()
+}
+
+// BlocksLeft returns the number of blocks left to clear before the block goal of the mode is reached
+func (b *Board) BlocksLeft() int {
+	if b.BlocksCleared >= b.Mode.BlockGoal {
+		return 0
+	}
+	return b.Mode.BlockGoal - b.BlocksCleared
+}
+
+// CheckModeLimits ends the game once the time limit runs out or the block goal is reached - either way the mode has been completed
+func (b *Board) CheckModeLimits() {
+	if b.Mode.BlockGoal > 0 && b.BlocksCleared >= b.Mode.BlockGoal {
+		b.ModeCompleted = true
+		b.GameOver = true
+	}
+
+	// The frame being stepped counts towards the time played
+	if b.Mode.TimeLimit > 0 && b.PlayTime()+FrameTime >= b.Mode.TimeLimit {
+		b.ModeCompleted = true
+		b.GameOver = true
+	}
+}
@@ -0,0 +1,94 @@
+package boardmodel
+
+// HasPartner returns true while the piece of player 'k' is the pivot of a falling pair
+func (b *Board) HasPartner(k int) bool {
+	return b.Pieces[k].Partner.X != -1 && b.Pieces[k].Partner.Y != -1
+}
+
+// pairCellFree returns true if a half of the pair of player 'k' can move into 'p'
+func (b *Board) pairCellFree(k int, p Pos) bool {
+	if p.X < 0 || p.X >= b.Width || p.Y < 0 || p.Y >= b.Height {
+		return false
+	}
+	return b.BlockStates[p.Y][p.X] == Empty || b.isActiveBlock(k, p)
+}
+
+// placePair moves the pivot of player 'k' to 'pivot' and the partner to 'partner', carrying their colors with them
+func (b *Board) placePair(k int, pivot, partner Pos) {
+	piece := &b.Pieces[k]
+
+	pivotColor := b.BlockColors[piece.Pos.Y][piece.Pos.X]
+	partnerColor := b.BlockColors[piece.Partner.Y][piece.Partner.X]
+
+	b.BlockStates[piece.Pos.Y][piece.Pos.X] = Empty
+	b.BlockStates[piece.Partner.Y][piece.Partner.X] = Empty
+
+	piece.Pos = pivot
+	piece.Partner = partner
+
+	b.BlockStates[pivot.Y][pivot.X] = Active
+	b.BlockColors[pivot.Y][pivot.X] = pivotColor
+	b.BlockStates[partner.Y][partner.X] = Active
+	b.BlockColors[partner.Y][partner.X] = partnerColor
+}
+
+// MovePair moves both halves of the pair of player 'k' by dx, dy - the pair stays put if either half is blocked
+func (b *Board) MovePair(k, dx, dy int) bool {
+	pivot := Pos{b.Pieces[k].Pos.X + dx, b.Pieces[k].Pos.Y + dy}
+	partner := Pos{b.Pieces[k].Partner.X + dx, b.Pieces[k].Partner.Y + dy}
+
+	if b.pairCellFree(k, pivot) == false || b.pairCellFree(k, partner) == false {
+		return false
+	}
+
+	b.placePair(k, pivot, partner)
+	return true
+}
+
+// RotatePair turns the partner of player 'k' a quarter turn around the pivot
+// A pair that does not fit is kicked one block away from the wall or block in its way, and a pair stuck in a one block wide gap is flipped over instead
+func (b *Board) RotatePair(k int, clockwise bool) bool {
+	piece := b.Pieces[k]
+	offset := Pos{piece.Partner.X - piece.Pos.X, piece.Partner.Y - piece.Pos.Y}
+
+	turned := Pos{-offset.Y, offset.X}
+	if clockwise == false {
+		turned = Pos{offset.Y, -offset.X}
+	}
+	flipped := Pos{-offset.X, -offset.Y}
+
+	for _, o := range []Pos{turned, flipped} {
+		// Try the turn in place first, then with the pivot pushed away from the partner
+		for _, kick := range []Pos{{0, 0}, {-o.X, -o.Y}} {
+			pivot := Pos{piece.Pos.X + kick.X, piece.Pos.Y + kick.Y}
+			partner := Pos{pivot.X + o.X, pivot.Y + o.Y}
+			if b.pairCellFree(k, pivot) == true && b.pairCellFree(k, partner) == true {
+				b.placePair(k, pivot, partner)
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// pairLanded returns true when either half of the pair of player 'k' rests on the bottom of the board or on a settled block
+func (b *Board) pairLanded(k int) bool {
+	for _, p := range b.PieceBlocks(k) {
+		if p.Y == b.Height-1 || b.BlockStates[p.Y+1][p.X] == Inactive {
+			return true
+		}
+	}
+	return false
+}
+
+// spawnPair starts a new pair for player 'k' at the top of 'column' with the partner above the pivot
+func (b *Board) spawnPair(k, column int) {
+	b.Pieces[k].Pos = Pos{column, 1}
+	b.Pieces[k].Partner = Pos{column, 0}
+
+	b.BlockStates[1][column] = Active
+	b.BlockColors[1][column] = b.popQueue()
+	b.BlockStates[0][column] = Active
+	b.BlockColors[0][column] = b.popQueue()
+}
@@ -0,0 +1,75 @@
+package boardmodel_test
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"testing"
+)
+
+// setPair makes a red pivot at 'pivot' and a blue partner at 'partner' the active pair of 'b'
+func setPair(b *boardmodel.Board, pivot, partner boardmodel.Pos) {
+	b.Pieces[0].Pos = pivot
+	b.Pieces[0].Partner = partner
+	b.BlockStates[pivot.Y][pivot.X] = boardmodel.Active
+	b.BlockColors[pivot.Y][pivot.X] = boardmodel.Red
+	b.BlockStates[partner.Y][partner.X] = boardmodel.Active
+	b.BlockColors[partner.Y][partner.X] = boardmodel.Blue
+}
+
+func TestRotatePair(t *testing.T) {
+	tests := []struct {
+		name           string
+		settled        []boardmodel.Pos
+		clockwise      bool
+		pivot, partner boardmodel.Pos
+		wantPivot      boardmodel.Pos
+		wantPartner    boardmodel.Pos
+	}{
+		{"clockwise in the open", nil, true, boardmodel.Pos{2, 5}, boardmodel.Pos{2, 4}, boardmodel.Pos{2, 5}, boardmodel.Pos{3, 5}},
+		{"counter-clockwise in the open", nil, false, boardmodel.Pos{2, 5}, boardmodel.Pos{2, 4}, boardmodel.Pos{2, 5}, boardmodel.Pos{1, 5}},
+		{"kicked off the right wall", nil, true, boardmodel.Pos{4, 5}, boardmodel.Pos{4, 4}, boardmodel.Pos{3, 5}, boardmodel.Pos{4, 5}},
+		{"kicked off the left wall", nil, false, boardmodel.Pos{0, 5}, boardmodel.Pos{0, 4}, boardmodel.Pos{1, 5}, boardmodel.Pos{0, 5}},
+		{"kicked off a settled block", []boardmodel.Pos{{3, 5}}, true, boardmodel.Pos{2, 5}, boardmodel.Pos{2, 4}, boardmodel.Pos{1, 5}, boardmodel.Pos{2, 5}},
+		{"flipped in a one block wide gap", []boardmodel.Pos{{1, 5}, {3, 5}}, true, boardmodel.Pos{2, 5}, boardmodel.Pos{2, 4}, boardmodel.Pos{2, 5}, boardmodel.Pos{2, 6}},
+		{"flipped over on the floor of a gap", []boardmodel.Pos{{1, 5}, {3, 5}, {2, 6}}, true, boardmodel.Pos{2, 5}, boardmodel.Pos{2, 4}, boardmodel.Pos{2, 4}, boardmodel.Pos{2, 5}},
+	}
+
+	for _, test := range tests {
+		b := emptyBoard(1)
+		place(b, boardmodel.Gray, test.settled...)
+		setPair(b, test.pivot, test.partner)
+
+		if b.RotatePair(0, test.clockwise) == false {
+			t.Errorf("%s: the pair did not turn", test.name)
+			continue
+		}
+		if b.Pieces[0].Pos != test.wantPivot || b.Pieces[0].Partner != test.wantPartner {
+			t.Errorf("%s: turned to %v and %v, expected %v and %v", test.name, b.Pieces[0].Pos, b.Pieces[0].Partner, test.wantPivot, test.wantPartner)
+			continue
+		}
+		if b.BlockColors[b.Pieces[0].Pos.Y][b.Pieces[0].Pos.X] != boardmodel.Red || b.BlockColors[b.Pieces[0].Partner.Y][b.Pieces[0].Partner.X] != boardmodel.Blue {
+			t.Errorf("%s: the halves of the pair swapped colors", test.name)
+		}
+		for _, p := range test.settled {
+			if b.BlockStates[p.Y][p.X] != boardmodel.Inactive || b.BlockColors[p.Y][p.X] != boardmodel.Gray {
+				t.Errorf("%s: the settled block at %v was overwritten", test.name, p)
+			}
+		}
+	}
+}
+
+func TestPairMovesAsOne(t *testing.T) {
+	b := emptyBoard(1)
+	place(b, boardmodel.Gray, boardmodel.Pos{1, 4})
+	setPair(b, boardmodel.Pos{2, 5}, boardmodel.Pos{2, 4})
+
+	// The partner is blocked on the left, so neither half moves
+	if b.MovePair(0, -1, 0) == true || b.Pieces[0].Pos != (boardmodel.Pos{2, 5}) || b.Pieces[0].Partner != (boardmodel.Pos{2, 4}) {
+		t.Errorf("moved left to %v and %v past a settled block", b.Pieces[0].Pos, b.Pieces[0].Partner)
+	}
+	if b.MovePair(0, 1, 0) == false || b.Pieces[0].Pos != (boardmodel.Pos{3, 5}) || b.Pieces[0].Partner != (boardmodel.Pos{3, 4}) {
+		t.Errorf("moved right to %v and %v, expected {3 5} and {3 4}", b.Pieces[0].Pos, b.Pieces[0].Partner)
+	}
+	if b.BlockStates[5][2] != boardmodel.Empty || b.BlockStates[4][2] != boardmodel.Empty {
+		t.Errorf("the pair left blocks behind")
+	}
+}
@@ -0,0 +1,97 @@
+package boardmodel
+
+import (
+	"sort"
+	"strconv"
+)
+
+// MaxPlayers is the most players that can share a board, each controlling a piece of their own
+const MaxPlayers = 2
+
+// Piece is the block, or pair of blocks, that one player is controlling - a piece that is waiting to be spawned is at -1, -1
+// Pos is the single block or the pivot of a pair, and HoldUsed is set once the piece has been swapped with the hold cell
+type Piece struct {
+	Pos      Pos
+	Partner  Pos
+	HoldUsed bool
+}
+
+// SetPlayers gives the board a piece for each of 'players' players, with no blocks in play - it should be called before a game is started
+func (b *Board) SetPlayers(players int) {
+	if players < 1 || players > MaxPlayers {
+		panic("ERROR: SetPlayers requires between 1 and MaxPlayers players. You have: " + strconv.Itoa(players))
+	}
+
+	b.Pieces = make([]Piece, players)
+	for k := range b.Pieces {
+		b.Pieces[k] = Piece{Pos: Pos{-1, -1}, Partner: Pos{-1, -1}}
+	}
+}
+
+// SpawnColumn returns the column the piece of player 'k' appears in - the players share the width of the board out evenly
+func (b *Board) SpawnColumn(k int) int {
+	return b.Width * (2*k + 1) / (2 * len(b.Pieces))
+}
+
+// HasPiece returns true while player 'k' has a block in play
+func (b *Board) HasPiece(k int) bool {
+	return b.Pieces[k].Pos.X != -1 && b.Pieces[k].Pos.Y != -1
+}
+
+// HasPieces returns true while any player has a block in play
+func (b *Board) HasPieces() bool {
+	for k := range b.Pieces {
+		if b.HasPiece(k) == true {
+			return true
+		}
+	}
+	return false
+}
+
+// PieceBlocks returns the positions of the blocks player 'k' is controlling
+func (b *Board) PieceBlocks(k int) []Pos {
+	if b.HasPiece(k) == false {
+		return nil
+	}
+	if b.HasPartner(k) == true {
+		return []Pos{b.Pieces[k].Pos, b.Pieces[k].Partner}
+	}
+	return []Pos{b.Pieces[k].Pos}
+}
+
+// ActiveBlocks returns the positions of every block the players are controlling, piece by piece
+func (b *Board) ActiveBlocks() []Pos {
+	active := []Pos{}
+	for k := range b.Pieces {
+		active = append(active, b.PieceBlocks(k)...)
+	}
+	return active
+}
+
+// isActiveBlock returns true if 'p' is one of the blocks player 'k' is controlling - the blocks of other players get in the way like any other block
+func (b *Board) isActiveBlock(k int, p Pos) bool {
+	return p == b.Pieces[k].Pos || (b.HasPartner(k) == true && p == b.Pieces[k].Partner)
+}
+
+// pieceOrder returns the players lowest piece first, so that a piece resting on another one follows it down when they fall together
+func (b *Board) pieceOrder() []int {
+	order := make([]int, len(b.Pieces))
+	for k := range order {
+		order[k] = k
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return b.pieceBottom(order[i]) > b.pieceBottom(order[j])
+	})
+	return order
+}
+
+// pieceBottom returns the row of the lowest block of player 'k', or -1 if they have no block in play
+func (b *Board) pieceBottom(k int) int {
+	bottom := -1
+	for _, p := range b.PieceBlocks(k) {
+		if p.Y > bottom {
+			bottom = p.Y
+		}
+	}
+	return bottom
+}
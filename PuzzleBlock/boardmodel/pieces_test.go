@@ -0,0 +1,93 @@
+package boardmodel_test
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"testing"
+)
+
+// coopBoard returns a wide board shared by two players that deals blocks from 'seed', with both pieces spawned
+func coopBoard(seed int64) *boardmodel.Board {
+	b := boardmodel.NewBoard(10, 10, seed)
+	b.SetPlayers(2)
+	b.Step()
+	return b
+}
+
+// setPiece moves the block of player 'k' to 'p' and makes it 'color'
+func setPiece(b *boardmodel.Board, k int, p boardmodel.Pos, color boardmodel.Color) {
+	old := b.Pieces[k].Pos
+	b.BlockStates[old.Y][old.X] = boardmodel.Empty
+	b.Pieces[k].Pos = p
+	b.BlockStates[p.Y][p.X] = boardmodel.Active
+	b.BlockColors[p.Y][p.X] = color
+}
+
+func TestPiecesSpawnInTheirOwnColumns(t *testing.T) {
+	b := coopBoard(1)
+
+	for k, want := range []boardmodel.Pos{{2, 0}, {7, 0}} {
+		if b.Pieces[k].Pos != want || b.BlockStates[want.Y][want.X] != boardmodel.Active {
+			t.Errorf("player %d spawned at %v, expected an active block at %v", k, b.Pieces[k].Pos, want)
+		}
+	}
+}
+
+func TestPiecesCanNotMoveIntoEachOther(t *testing.T) {
+	b := coopBoard(1)
+	setPiece(b, 0, boardmodel.Pos{4, 5}, boardmodel.Red)
+	setPiece(b, 1, boardmodel.Pos{5, 5}, boardmodel.Blue)
+
+	b.MovePiece(0, "right")
+	b.MovePiece(1, "left")
+	if b.Pieces[0].Pos != (boardmodel.Pos{4, 5}) || b.Pieces[1].Pos != (boardmodel.Pos{5, 5}) {
+		t.Errorf("pieces moved to %v and %v, expected them to block each other", b.Pieces[0].Pos, b.Pieces[1].Pos)
+	}
+	if b.BlockColors[5][4] != boardmodel.Red || b.BlockColors[5][5] != boardmodel.Blue {
+		t.Errorf("pieces are colored %d and %d, expected them untouched", b.BlockColors[5][4], b.BlockColors[5][5])
+	}
+
+	// A piece above another one can only be pushed down onto it
+	setPiece(b, 1, boardmodel.Pos{4, 3}, boardmodel.Blue)
+	b.MovePiece(1, "down")
+	b.MovePiece(1, "down")
+	if b.Pieces[1].Pos != (boardmodel.Pos{4, 4}) {
+		t.Errorf("the upper piece moved to %v, expected it to stop on the lower one at {4 4}", b.Pieces[1].Pos)
+	}
+}
+
+func TestPiecesFallTogether(t *testing.T) {
+	b := coopBoard(1)
+	setPiece(b, 0, boardmodel.Pos{4, 5}, boardmodel.Red)
+	setPiece(b, 1, boardmodel.Pos{4, 4}, boardmodel.Blue)
+
+	// The lower piece moves first, so the one resting on it follows it down
+	b.MovePiece(0, "fall")
+	if b.Pieces[0].Pos != (boardmodel.Pos{4, 6}) || b.Pieces[1].Pos != (boardmodel.Pos{4, 5}) {
+		t.Errorf("pieces fell to %v and %v, expected {4 6} and {4 5}", b.Pieces[0].Pos, b.Pieces[1].Pos)
+	}
+}
+
+func TestPieceRestingOnAnotherWaitsForItToLand(t *testing.T) {
+	b := coopBoard(1)
+	setPiece(b, 0, boardmodel.Pos{4, 8}, boardmodel.Red)
+	setPiece(b, 1, boardmodel.Pos{4, 7}, boardmodel.Blue)
+
+	b.Step()
+	if b.HasPiece(0) == false || b.HasPiece(1) == false {
+		t.Fatalf("a piece landed in mid air - players have pieces: %v and %v", b.HasPiece(0), b.HasPiece(1))
+	}
+
+	// The ghost of the upper piece sits on the lower one, and dropping both stacks them on the floor
+	if ghosts := b.PieceGhostBlocks(1); ghosts[0] != (boardmodel.Pos{4, 7}) {
+		t.Errorf("the upper ghost is at %v, expected it on the lower piece at {4 7}", ghosts[0])
+	}
+	b.MovePiece(0, "drop")
+	b.MovePiece(1, "drop")
+	b.Step()
+
+	if b.BlockStates[9][4] != boardmodel.Inactive || b.BlockColors[9][4] != boardmodel.Red ||
+		b.BlockStates[8][4] != boardmodel.Inactive || b.BlockColors[8][4] != boardmodel.Blue {
+		t.Errorf("column 4 ends with %d of color %d under %d of color %d, expected red under blue settled on the floor",
+			b.BlockStates[9][4], b.BlockColors[9][4], b.BlockStates[8][4], b.BlockColors[8][4])
+	}
+}
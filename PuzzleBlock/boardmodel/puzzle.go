@@ -0,0 +1,263 @@
+package boardmodel
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// GoalKind denotes what has to be done to solve a puzzle
+type GoalKind string
+
+const (
+	// ClearGray puzzles are solved once there are no Gray blocks left on the board
+	ClearGray GoalKind = "clear_gray"
+	// ClearAll puzzles are solved once the board is empty
+	ClearAll GoalKind = "clear_all"
+	// ReachScore puzzles are solved once the score reaches the target
+	ReachScore GoalKind = "score"
+	// ReachChain puzzles are solved once a chain as long as the target has been made
+	ReachChain GoalKind = "chain"
+)
+
+// Goal is what has to be done to solve a puzzle before its pieces run out
+type Goal struct {
+	Kind   GoalKind `json:"kind"`
+	Target int      `json:"target"`
+}
+
+// Puzzle is a hand-made starting board with a fixed sequence of pieces and a goal
+// Rows are read top to bottom and sit on the floor of the board - each letter is a block: R, G, B, Y, V and W for the colors, X for Gray, M for Multi, O for Bomb, L for Line, N for Nuke and . for an empty cell
+type Puzzle struct {
+	Name   string   `json:"name"`
+	Rows   []string `json:"rows"`
+	Pieces string   `json:"pieces"`
+	Goal   Goal     `json:"goal"`
+	DeGray int      `json:"de_gray"`
+	Rules  *Rules   `json:"rules,omitempty"`
+}
+
+// puzzleLetters maps the letters of a puzzle file to block colors
+var puzzleLetters = map[rune]Color{
+	'R': Red,
+	'G': Green,
+	'B': Blue,
+	'Y': Yellow,
+	'V': Violet,
+	'W': White,
+	'X': Gray,
+	'M': Multi,
+	'O': Bomb,
+	'L': Line,
+	'N': Nuke,
+}
+
+// String returns the goal as it is shown to the player, e.g. Score 500 points
+func (g Goal) String() string {
+	switch g.Kind {
+	case ClearGray:
+		return "Clear all gray blocks"
+	case ClearAll:
+		return "Clear the board"
+	case ReachScore:
+		return "Score " + strconv.Itoa(g.Target) + " points"
+	case ReachChain:
+		return "Make a chain of " + strconv.Itoa(g.Target)
+	default:
+	}
+	return string(g.Kind)
+}
+
+// LoadPuzzle reads a puzzle from a JSON file
+func LoadPuzzle(path string) (*Puzzle, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Puzzle{}
+	err = json.Unmarshal(data, p)
+	if err != nil {
+		return nil, errors.New("puzzle: " + path + ": " + err.Error())
+	}
+
+	return p, nil
+}
+
+// LoadPuzzles reads every puzzle file in a folder, in the order of their file names
+func LoadPuzzles(dir string) ([]*Puzzle, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	puzzles := make([]*Puzzle, 0, len(paths))
+	for _, path := range paths {
+		p, err := LoadPuzzle(path)
+		if err != nil {
+			return nil, err
+		}
+		puzzles = append(puzzles, p)
+	}
+
+	return puzzles, nil
+}
+
+// Validate returns an error if the puzzle can not be played on a board of 'width' by 'height' blocks
+func (p *Puzzle) Validate(width, height int) error {
+	name := "puzzle: " + p.Name
+
+	// Leave the top row free for the pieces to come in
+	if len(p.Rows) >= height {
+		return errors.New(name + " has " + strconv.Itoa(len(p.Rows)) + " rows, the board only has room for " + strconv.Itoa(height-1))
+	}
+	for j, row := range p.Rows {
+		if len([]rune(row)) != width {
+			return errors.New(name + ": row " + strconv.Itoa(j+1) + " needs to be " + strconv.Itoa(width) + " blocks wide")
+		}
+		for _, r := range row {
+			if _, ok := puzzleLetters[r]; ok == false && r != '.' {
+				return errors.New(name + ": row " + strconv.Itoa(j+1) + " has an unknown block '" + string(r) + "'")
+			}
+		}
+	}
+
+	if len(p.Pieces) == 0 {
+		return errors.New(name + " needs at least one piece")
+	}
+	for _, r := range p.Pieces {
+		if _, ok := puzzleLetters[r]; ok == false {
+			return errors.New(name + " has an unknown piece '" + string(r) + "'")
+		}
+	}
+
+	switch p.Goal.Kind {
+	case ClearGray, ClearAll:
+	case ReachScore, ReachChain:
+		if p.Goal.Target <= 0 {
+			return errors.New(name + " needs a goal target above 0")
+		}
+	default:
+		return errors.New(name + " has an unknown goal \"" + string(p.Goal.Kind) + "\"")
+	}
+
+	if p.DeGray < 0 {
+		return errors.New(name + " can not have a negative de_gray")
+	}
+	if p.Rules != nil {
+		return p.Rules.Validate()
+	}
+
+	return nil
+}
+
+// SetPuzzle lays the puzzle out on a board that has just been started and deals its pieces instead of random ones
+// Puzzles are played one block at a time with no time limit, under their own rules or the default rules if they have none
+func (b *Board) SetPuzzle(p *Puzzle) error {
+	err := p.Validate(b.Width, b.Height)
+	if err != nil {
+		return err
+	}
+
+	b.Puzzle = p
+	b.PairMode = false
+	b.Mode = EndlessMode()
+	b.Rules = DefaultRules()
+	if p.Rules != nil {
+		b.Rules = *p.Rules
+	}
+	if p.DeGray > 0 {
+		b.DeGrayValue = p.DeGray
+	}
+
+	top := b.Height - len(p.Rows)
+	for j, row := range p.Rows {
+		for i, r := range []rune(row) {
+			if r == '.' {
+				b.BlockStates[top+j][i] = Empty
+			} else {
+				b.BlockStates[top+j][i] = Inactive
+				b.BlockColors[top+j][i] = puzzleLetters[r]
+			}
+		}
+	}
+
+	pieces := make([]Color, 0, len(p.Pieces))
+	for _, r := range p.Pieces {
+		pieces = append(pieces, puzzleLetters[r])
+	}
+	b.SetGenerator(NewSequenceGenerator(pieces))
+
+	return nil
+}
+
+// PiecesLeft returns how many pieces of the puzzle are still to come, or -1 if there is no puzzle
+func (b *Board) PiecesLeft() int {
+	if b.Puzzle == nil {
+		return -1
+	}
+	return len(b.Puzzle.Pieces) - b.PiecesUsed
+}
+
+// GoalMet returns true if the goal of the puzzle has been reached
+func (b *Board) GoalMet() bool {
+	switch b.Puzzle.Goal.Kind {
+	case ClearGray:
+		for j := range b.BlockStates {
+			for i := range b.BlockStates[j] {
+				if b.BlockStates[j][i] != Empty && b.BlockColors[j][i] == Gray {
+					return false
+				}
+			}
+		}
+		return true
+	case ClearAll:
+		for j := range b.BlockStates {
+			for i := range b.BlockStates[j] {
+				if b.BlockStates[j][i] != Empty {
+					return false
+				}
+			}
+		}
+		return true
+	case ReachScore:
+		return b.ScoreValue >= b.Puzzle.Goal.Target
+	case ReachChain:
+		return b.MaxChain >= b.Puzzle.Goal.Target
+	default:
+	}
+	return false
+}
+
+// Settled returns true if no block is falling or being cleared
+func (b *Board) Settled() bool {
+	if b.BlockScorePausing == true {
+		return false
+	}
+	for j := range b.BlockStates {
+		for i := range b.BlockStates[j] {
+			if b.BlockStates[j][i] == Inactive && j+1 < b.Height && b.BlockStates[j+1][i] == Empty {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CheckPuzzle ends a puzzle as soon as its goal is met, or once the last piece has landed and the board has settled without meeting it
+func (b *Board) CheckPuzzle() {
+	if b.Puzzle == nil {
+		return
+	}
+
+	if b.GoalMet() == true {
+		b.PuzzleSolved = true
+		b.GameOver = true
+	} else if b.PiecesLeft() == 0 && b.HasPieces() == false && b.Settled() == true {
+		b.GameOver = true
+	}
+}
@@ -0,0 +1,109 @@
+package boardmodel_test
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"testing"
+)
+
+// cleanSweep is a puzzle whose two pieces clear the board when they are left to fall
+func cleanSweep() *boardmodel.Puzzle {
+	return &boardmodel.Puzzle{Name: "Clean Sweep", Rows: []string{"GG.YY"}, Pieces: "GY", Goal: boardmodel.Goal{Kind: boardmodel.ClearAll}}
+}
+
+// playPuzzle starts 'p' on a new board and lets its pieces fall until the puzzle is over
+func playPuzzle(t *testing.T, p *boardmodel.Puzzle) *boardmodel.Board {
+	b := emptyBoard(1)
+	err := b.SetPuzzle(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for f := 0; f < 20000 && b.GameOver == false; f++ {
+		b.Step()
+	}
+	if b.GameOver == false {
+		t.Fatalf("%s: the puzzle is not over after %d frames", p.Name, b.Frame)
+	}
+	return b
+}
+
+func TestShippedPuzzlesAreValid(t *testing.T) {
+	puzzles, err := boardmodel.LoadPuzzles("../assets/puzzles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(puzzles) == 0 {
+		t.Fatal("found no puzzles")
+	}
+	for _, p := range puzzles {
+		err = emptyBoard(1).SetPuzzle(p)
+		if err != nil {
+			t.Errorf("%s: %v", p.Name, err)
+		}
+	}
+}
+
+func TestPuzzleIsLaidOutOnTheFloor(t *testing.T) {
+	b := emptyBoard(1)
+	err := b.SetPuzzle(cleanSweep())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	colors := []boardmodel.Color{boardmodel.Green, boardmodel.Green, 0, boardmodel.Yellow, boardmodel.Yellow}
+	for i, c := range colors {
+		filled := i != 2
+		if (b.BlockStates[9][i] == boardmodel.Inactive) != filled || (filled == true && b.BlockColors[9][i] != c) {
+			t.Errorf("block at {%d 9} is %d of color %d", i, b.BlockStates[9][i], b.BlockColors[9][i])
+		}
+	}
+	if b.Queue[0] != boardmodel.Green || b.Queue[1] != boardmodel.Yellow || b.PiecesLeft() != 2 {
+		t.Errorf("queue starts %v with %d pieces left, expected green and yellow with 2", b.Queue[:2], b.PiecesLeft())
+	}
+}
+
+func TestPuzzleIsSolvedWhenItsGoalIsMet(t *testing.T) {
+	b := playPuzzle(t, cleanSweep())
+	if b.PuzzleSolved == false {
+		t.Errorf("the board was not cleared - score %d with %d pieces left", b.ScoreValue, b.PiecesLeft())
+	}
+}
+
+func TestPuzzleFailsWhenItsPiecesRunOut(t *testing.T) {
+	p := cleanSweep()
+	p.Pieces = "R"
+
+	b := playPuzzle(t, p)
+	if b.PuzzleSolved == true {
+		t.Errorf("solved with a piece that matches nothing")
+	}
+	if b.PiecesLeft() != 0 {
+		t.Errorf("ended with %d pieces left, expected 0", b.PiecesLeft())
+	}
+}
+
+func TestPuzzleValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(p *boardmodel.Puzzle)
+	}{
+		{"too many rows", func(p *boardmodel.Puzzle) { p.Rows = make([]string, 10) }},
+		{"a row of the wrong width", func(p *boardmodel.Puzzle) { p.Rows = []string{"GG.Y"} }},
+		{"an unknown block", func(p *boardmodel.Puzzle) { p.Rows = []string{"GG.YQ"} }},
+		{"no pieces", func(p *boardmodel.Puzzle) { p.Pieces = "" }},
+		{"an unknown piece", func(p *boardmodel.Puzzle) { p.Pieces = "G." }},
+		{"an unknown goal", func(p *boardmodel.Puzzle) { p.Goal.Kind = "win" }},
+		{"a score goal without a target", func(p *boardmodel.Puzzle) { p.Goal = boardmodel.Goal{Kind: boardmodel.ReachScore} }},
+		{"a negative de_gray", func(p *boardmodel.Puzzle) { p.DeGray = -1 }},
+	}
+
+	if err := cleanSweep().Validate(5, 10); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		p := cleanSweep()
+		test.change(p)
+		if p.Validate(5, 10) == nil {
+			t.Errorf("%s: validated, expected an error", test.name)
+		}
+	}
+}
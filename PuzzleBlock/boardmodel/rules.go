@@ -0,0 +1,78 @@
+package boardmodel
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"strconv"
+)
+
+// MatchMode decides the shape blocks have to be in to score
+type MatchMode string
+
+const (
+	// LineMatches score straight lines of blocks
+	LineMatches MatchMode = "lines"
+	// GroupMatches score groups of blocks connected in any shape through their sides
+	GroupMatches MatchMode = "groups"
+)
+
+// Rules holds the settings that decide what counts as a match - MinMatch is the length of a line or the size of a group
+type Rules struct {
+	Mode            MatchMode `json:"match_mode"`
+	MinMatch        int       `json:"min_match"`
+	Diagonals       bool      `json:"diagonals"`
+	WildcardMatches bool      `json:"wildcard_matches"`
+	GrayMatches     bool      `json:"gray_matches"`
+}
+
+// DefaultRules returns the rules of the standard game - straight lines of three or more in any of the eight directions, with Multi blocks taking on the color of the block they land on and Gray blocks never matching
+func DefaultRules() Rules {
+	return Rules{Mode: LineMatches, MinMatch: 3, Diagonals: true, WildcardMatches: false, GrayMatches: false}
+}
+
+// LoadRules reads a set of rules from a JSON file - settings missing from the file keep their default values
+func LoadRules(path string) (Rules, error) {
+	r := DefaultRules()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return r, err
+	}
+
+	err = json.Unmarshal(data, &r)
+	if err != nil {
+		return DefaultRules(), err
+	}
+
+	return r, r.Validate()
+}
+
+// Validate returns an error if the rules can not be played with
+func (r Rules) Validate() error {
+	if r.Mode != LineMatches && r.Mode != GroupMatches {
+		return errors.New("rules: match_mode must be \"" + string(LineMatches) + "\" or \"" + string(GroupMatches) + "\", not \"" + string(r.Mode) + "\"")
+	}
+	if r.MinMatch < 3 || r.MinMatch > 5 {
+		return errors.New("rules: min_match must be 3, 4 or 5, not " + strconv.Itoa(r.MinMatch))
+	}
+	return nil
+}
+
+// Matches returns true if a block of color 'a' and a block of color 'c' count towards the same match
+// Gray blocks only match each other, and only if GrayMatches is set - Multi blocks match any color but Gray if WildcardMatches is set, Bomb and Line blocks match any other color but Gray and Nuke blocks never match
+func (r Rules) Matches(a, c Color) bool {
+	if a == Gray || c == Gray {
+		return r.GrayMatches == true && a == Gray && c == Gray
+	}
+	if a == Nuke || c == Nuke {
+		return false
+	}
+	if a == Multi || c == Multi {
+		return r.WildcardMatches == true
+	}
+	if a == Bomb || a == Line || c == Bomb || c == Line {
+		return true
+	}
+	return a == c
+}
@@ -0,0 +1,103 @@
+package boardmodel_test
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"testing"
+)
+
+// block is a settled block of a color at a position, for laying out test boards
+type block struct {
+	color boardmodel.Color
+	pos   boardmodel.Pos
+}
+
+// row returns settled blocks of 'colors' from the left of row 'y'
+func row(y int, colors ...boardmodel.Color) []block {
+	blocks := make([]block, len(colors))
+	for i, c := range colors {
+		blocks[i] = block{c, boardmodel.Pos{i, y}}
+	}
+	return blocks
+}
+
+// cleared returns how many of 'blocks' are gone from 'b'
+func cleared(b *boardmodel.Board, blocks []block) int {
+	n := 0
+	for _, bl := range blocks {
+		if b.BlockStates[bl.pos.Y][bl.pos.X] == boardmodel.Empty {
+			n++
+		}
+	}
+	return n
+}
+
+func TestRulesDecideWhatClears(t *testing.T) {
+	const (
+		R = boardmodel.Red
+		G = boardmodel.Green
+		B = boardmodel.Blue
+		X = boardmodel.Gray
+		M = boardmodel.Multi
+	)
+	defaults := boardmodel.DefaultRules()
+	withMinMatch := func(n int) boardmodel.Rules {
+		r := defaults
+		r.MinMatch = n
+		return r
+	}
+	withoutDiagonals := defaults
+	withoutDiagonals.Diagonals = false
+	withWildcards := defaults
+	withWildcards.WildcardMatches = true
+	withGray := defaults
+	withGray.GrayMatches = true
+
+	diagonal := []block{{R, boardmodel.Pos{0, 9}}, {R, boardmodel.Pos{1, 8}}, {R, boardmodel.Pos{2, 7}}}
+	// A Multi block in a row of red, standing on green so that it turns green when it is not wild
+	wildRow := append(row(8, R, M, R), row(9, B, G, B)...)
+
+	tests := []struct {
+		name    string
+		rules   boardmodel.Rules
+		blocks  []block
+		cleared int
+	}{
+		{"three in a row with a minimum of 3", withMinMatch(3), row(9, R, R, R, G), 3},
+		{"three in a row with a minimum of 4", withMinMatch(4), row(9, R, R, R, G), 0},
+		{"four in a row with a minimum of 4", withMinMatch(4), row(9, R, R, R, R, G), 4},
+		{"four in a row with a minimum of 5", withMinMatch(5), row(9, R, R, R, R, G), 0},
+		{"five in a row with a minimum of 5", withMinMatch(5), row(9, R, R, R, R, R), 5},
+		{"a diagonal with diagonals on", defaults, diagonal, 3},
+		{"a diagonal with diagonals off", withoutDiagonals, diagonal, 0},
+		{"a Multi block in a row with wildcards off", defaults, wildRow, 0},
+		{"a Multi block in a row with wildcards on", withWildcards, wildRow, 3},
+		{"a row of Gray with gray matching off", defaults, row(9, X, X, X), 0},
+		{"a row of Gray with gray matching on", withGray, row(9, X, X, X), 3},
+		{"a Multi block between Gray with both on", boardmodel.Rules{MinMatch: 3, Diagonals: true, WildcardMatches: true, GrayMatches: true}, row(9, X, M, X), 0},
+	}
+
+	for _, test := range tests {
+		b := emptyBoard(1)
+		b.Rules = test.rules
+		for _, bl := range test.blocks {
+			place(b, bl.color, bl.pos)
+		}
+
+		b.Step()
+
+		if n := cleared(b, test.blocks); n != test.cleared {
+			t.Errorf("%s: cleared %d blocks, expected %d", test.name, n, test.cleared)
+		}
+	}
+}
+
+func TestRulesValidate(t *testing.T) {
+	for n := 0; n <= 6; n++ {
+		r := boardmodel.DefaultRules()
+		r.MinMatch = n
+		valid := n >= 3 && n <= 5
+		if err := r.Validate(); (err == nil) != valid {
+			t.Errorf("min_match %d: validated with %v", n, err)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package boardmodel
+
+// Wildcard returns true if blocks of color 'c' can stand in for another color in a match - Multi blocks only do so if the rules let them
+func (c Color) Wildcard() bool {
+	return c == Multi || c == Bomb || c == Line
+}
+
+// Special returns true if blocks of color 'c' set off an effect of their own
+func (c Color) Special() bool {
+	return c == Bomb || c == Line || c == Nuke
+}
+
+// setOffSpecials marks the blocks cleared by every Bomb and Line block that is about to explode - a Bomb takes the settled blocks around it with it and a Line its whole row
+// Special blocks caught up in the blast are set off too, except for Nuke blocks, which are simply cleared
+func (b *Board) setOffSpecials() {
+	specials := make([]Pos, 0)
+	for j := range b.BlockStates {
+		for i := range b.BlockStates[j] {
+			if b.BlockStates[j][i] == Exploding && (b.BlockColors[j][i] == Bomb || b.BlockColors[j][i] == Line) {
+				specials = append(specials, Pos{i, j})
+			}
+		}
+	}
+
+	for k := 0; k < len(specials); k++ {
+		p := specials[k]
+		color := b.BlockColors[p.Y][p.X]
+		b.SpecialsSetOff = append(b.SpecialsSetOff, color)
+		b.StepSpecials++
+
+		blast := make([]Pos, 0)
+		if color == Bomb {
+			for y := p.Y - 1; y <= p.Y+1; y++ {
+				for x := p.X - 1; x <= p.X+1; x++ {
+					blast = append(blast, Pos{x, y})
+				}
+			}
+		} else {
+			for x := 0; x < b.Width; x++ {
+				blast = append(blast, Pos{x, p.Y})
+			}
+		}
+
+		for _, n := range blast {
+			if n.X < 0 || n.X >= b.Width || n.Y < 0 || n.Y >= b.Height || b.BlockStates[n.Y][n.X] != Inactive {
+				continue
+			}
+			b.BlockStates[n.Y][n.X] = Exploding
+			if b.BlockColors[n.Y][n.X] == Bomb || b.BlockColors[n.Y][n.X] == Line {
+				specials = append(specials, n)
+			}
+		}
+	}
+}
+
+// SetOffNuke clears the Nuke block at 'p' once it has come to rest on a settled block or the floor, along with every settled block of the color it landed on
+// A Nuke that lands on the floor or on another Nuke clears only itself
+func (b *Board) SetOffNuke(p Pos) {
+	if p.Y+1 < b.Height && b.BlockStates[p.Y+1][p.X] != Inactive {
+		return
+	}
+
+	b.BlockStates[p.Y][p.X] = Exploding
+	if p.Y+1 < b.Height && b.BlockColors[p.Y+1][p.X] != Nuke {
+		target := b.BlockColors[p.Y+1][p.X]
+		for j := range b.BlockStates {
+			for i := range b.BlockStates[j] {
+				if b.BlockStates[j][i] == Inactive && b.BlockColors[j][i] == target {
+					b.BlockStates[j][i] = Exploding
+				}
+			}
+		}
+	}
+
+	b.SpecialsSetOff = append(b.SpecialsSetOff, Nuke)
+	b.StepSpecials++
+	b.clearExploding()
+}
@@ -0,0 +1,151 @@
+package boardmodel_test
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"testing"
+)
+
+const (
+	R = boardmodel.Red
+	G = boardmodel.Green
+	B = boardmodel.Blue
+	Y = boardmodel.Yellow
+	X = boardmodel.Gray
+	M = boardmodel.Multi
+	O = boardmodel.Bomb
+	L = boardmodel.Line
+	N = boardmodel.Nuke
+)
+
+// stepSpecials lays 'blocks' out on a new board and steps it once, so that anything matched is cleared
+func stepSpecials(blocks []block) *boardmodel.Board {
+	b := emptyBoard(1)
+	for _, bl := range blocks {
+		place(b, bl.color, bl.pos)
+	}
+	b.Step()
+	return b
+}
+
+// clearedColor returns the color 'b' reported the block at 'p' had when it was cleared, and false if it was not cleared
+func clearedColor(b *boardmodel.Board, p boardmodel.Pos) (boardmodel.Color, bool) {
+	for _, group := range b.ClearedBlocks {
+		for _, c := range group {
+			if c.Pos == p {
+				return c.Color, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func TestMatchesWithSpecialGems(t *testing.T) {
+	rules := boardmodel.DefaultRules()
+	wild := rules
+	wild.WildcardMatches = true
+
+	tests := []struct {
+		name  string
+		rules boardmodel.Rules
+		a, c  boardmodel.Color
+		want  bool
+	}{
+		{"a Bomb and a color", rules, O, R, true},
+		{"a Line and a color", rules, G, L, true},
+		{"a Bomb and a Line", rules, O, L, true},
+		{"a Bomb and Gray", rules, O, X, false},
+		{"a Multi and a Bomb with wildcards off", rules, M, O, false},
+		{"a Line and a Multi with wildcards off", rules, L, M, false},
+		{"a Multi and a Bomb with wildcards on", wild, M, O, true},
+		{"a Nuke and a color", rules, N, R, false},
+		{"a Nuke and a Bomb", rules, N, O, false},
+		{"two Nukes", wild, N, N, false},
+	}
+
+	for _, test := range tests {
+		if got := test.rules.Matches(test.a, test.c); got != test.want {
+			t.Errorf("%s: matches %v, expected %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestBombClearsTheBlocksAroundIt(t *testing.T) {
+	blast := append(row(9, R, O, R), row(8, G, B, Y)...)
+	outside := []block{{G, boardmodel.Pos{3, 9}}, {Y, boardmodel.Pos{3, 8}}, {B, boardmodel.Pos{4, 9}}}
+
+	b := stepSpecials(append(blast, outside...))
+
+	if n := cleared(b, blast); n != len(blast) {
+		t.Errorf("cleared %d of the %d blocks in the 3x3 around the Bomb", n, len(blast))
+	}
+	if n := cleared(b, outside); n != 0 {
+		t.Errorf("cleared %d blocks outside the blast", n)
+	}
+	if c, ok := clearedColor(b, boardmodel.Pos{1, 9}); ok == false || c != O {
+		t.Errorf("the Bomb was reported cleared %v as color %d", ok, c)
+	}
+	if c, ok := clearedColor(b, boardmodel.Pos{1, 8}); ok == false || c != B {
+		t.Errorf("the blue block above the Bomb was reported cleared %v as color %d", ok, c)
+	}
+}
+
+func TestLineClearsItsRow(t *testing.T) {
+	line := row(9, R, L, R, G, B)
+	above := []block{{Y, boardmodel.Pos{4, 8}}}
+
+	b := stepSpecials(append(line, above...))
+
+	if n := cleared(b, line); n != len(line) {
+		t.Errorf("cleared %d of the %d blocks in the row of the Line", n, len(line))
+	}
+	if n := cleared(b, above); n != 0 {
+		t.Errorf("cleared the block above the row")
+	}
+	if c, ok := clearedColor(b, boardmodel.Pos{3, 9}); ok == false || c != G {
+		t.Errorf("the green block in the row was reported cleared %v as color %d", ok, c)
+	}
+}
+
+func TestNukeClearsEveryBlockOfTheColorItLandsOn(t *testing.T) {
+	reds := []block{{R, boardmodel.Pos{0, 9}}, {R, boardmodel.Pos{4, 9}}, {R, boardmodel.Pos{2, 8}}}
+	others := []block{{G, boardmodel.Pos{1, 9}}, {B, boardmodel.Pos{2, 9}}, {G, boardmodel.Pos{3, 9}}}
+	nuke := []block{{N, boardmodel.Pos{0, 8}}}
+
+	b := stepSpecials(append(append(reds, others...), nuke...))
+
+	if n := cleared(b, append(reds, nuke...)); n != len(reds)+1 {
+		t.Errorf("cleared %d of the %d red blocks and the Nuke", n, len(reds)+1)
+	}
+	if n := cleared(b, others); n != 0 {
+		t.Errorf("cleared %d blocks of other colors", n)
+	}
+	if c, ok := clearedColor(b, boardmodel.Pos{4, 9}); ok == false || c != R {
+		t.Errorf("the red block across the board was reported cleared %v as color %d", ok, c)
+	}
+}
+
+func TestNukeOnTheFloorClearsOnlyItself(t *testing.T) {
+	others := row(9, R, R, B, G, G)
+	others[2] = block{N, boardmodel.Pos{2, 9}}
+
+	b := stepSpecials(others)
+
+	if n := cleared(b, others); n != 1 || b.BlockStates[9][2] != boardmodel.Empty {
+		t.Errorf("cleared %d blocks, expected only the Nuke", n)
+	}
+}
+
+func TestStandardLevelsDealNoSpecialGems(t *testing.T) {
+	shipped, err := boardmodel.LoadLevels("../assets/levels.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, levels := range map[string][]boardmodel.Level{"default": boardmodel.DefaultLevels(), "shipped": shipped} {
+		for l, level := range levels {
+			if level.BombChance != 0 || level.LineChance != 0 || level.NukeChance != 0 {
+				t.Errorf("%s level %d deals special gems: %+v", name, l+1, level)
+			}
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package boardmodel
+
+// FrameTime is the length in milliseconds of a single step of the board
+const FrameTime = 5.0
+
+// MaxFrameBacklog is the most time in milliseconds the board will catch up on in a single update
+const MaxFrameBacklog = 250.0
+
+// Update advances the board by 'time' milliseconds - the board always moves in whole steps of FrameTime so that a game plays out the same way regardless of the frame rate
+func (b *Board) Update(time float64) {
+	b.FrameTimer += time
+	if b.FrameTimer > MaxFrameBacklog {
+		b.FrameTimer = MaxFrameBacklog
+	}
+
+	for b.FrameTimer >= FrameTime && b.GameOver == false {
+		b.FrameTimer -= FrameTime
+		b.Step()
+	}
+}
+
+// Step advances the board by a single frame
+func (b *Board) Step() {
+
+	time := FrameTime
+
+	// Feed back any recorded inputs that belong to this frame
+	if b.PlayingBack == true {
+		for b.PlaybackIndex < len(b.PlaybackInputs) && b.PlaybackInputs[b.PlaybackIndex].Frame <= b.Frame {
+			b.applyInput(b.PlaybackInputs[b.PlaybackIndex].Player, b.PlaybackInputs[b.PlaybackIndex].Move)
+			b.PlaybackIndex++
+		}
+		if b.PlaybackIndex >= len(b.PlaybackInputs) {
+			b.PlayingBack = false
+		}
+	}
+
+	// Move the current block down once every fall interval of the current level - when playing back, the recorded 'fall' inputs do this instead
+	if b.LevelFall == false && b.LevelFallingTimer >= b.LevelFallingTime {
+		if b.PlayingBack == false {
+			b.applyInput(0, "fall")
+		}
+	} else if b.LevelFall == false && b.LevelFallingTimer < b.LevelFallingTime {
+		b.LevelFallingTimer += time
+	}
+
+	// Make sure there is a 'time buffer' between the last time we pressed 'down' and the next time the active block automatically falls
+	if b.LevelFall == true && b.LevelPostFallTimer >= b.LevelPostFallTime {
+		b.LevelFall = false
+		b.LevelPostFallTime = b.CurrentLevel().LockDelay
+		b.LevelPostFallTimer = 0
+	} else if b.LevelFall == true && b.LevelPostFallTimer < b.LevelPostFallTime {
+		b.LevelPostFallTimer += time
+	}
+
+	// Stop the downward descent of the current blocks - both halves of a pair settle as soon as one of them lands, and the other then falls on its own
+	// A piece resting on another player's piece waits for that one to land first
+	for _, k := range b.pieceOrder() {
+		piece := &b.Pieces[k]
+		if b.HasPartner(k) == true {
+			if b.pairLanded(k) == true {
+				b.BlockStates[piece.Pos.Y][piece.Pos.X] = Inactive
+				b.BlockStates[piece.Partner.Y][piece.Partner.X] = Inactive
+				piece.Pos = Pos{-1, -1}
+				piece.Partner = Pos{-1, -1}
+			}
+		} else if b.HasPiece(k) == true &&
+			(piece.Pos.Y == b.Height-1 || b.BlockStates[piece.Pos.Y+1][piece.Pos.X] == Inactive) {
+			b.BlockStates[piece.Pos.Y][piece.Pos.X] = Inactive
+			piece.Pos = Pos{-1, -1}
+		}
+	}
+
+	// Check for game over state which occurs when one column of blocks reaches the top of the board
+	currentYCount := make([]int, b.Width)
+	for j := range b.BlockStates {
+		for i := range b.BlockStates[j] {
+			if b.BlockStates[j][i] == Inactive {
+				currentYCount[i]++
+			}
+		}
+	}
+
+	columnFull := false
+	for k := range currentYCount {
+		if currentYCount[k] >= b.Height {
+			columnFull = true
+			break
+		}
+	}
+
+	// The game is over once a column has stayed full for GameOverTime - clearing blocks out of the column in time cancels it
+	if columnFull == true {
+		b.GameOverPausing = true
+		if b.GameOverTimer >= b.GameOverTime {
+			b.GameOver = true
+		} else {
+			b.GameOverTimer += time
+		}
+	} else {
+		b.GameOverPausing = false
+		b.GameOverTimer = 0
+	}
+
+	// Check for block scores
+	for j := range b.BlockStates {
+		for i := range b.BlockStates[j] {
+			if b.BlockStates[j][i] == Inactive {
+				// Nuke blocks go off as soon as they come to rest instead of matching
+				if b.BlockColors[j][i] == Nuke {
+					b.SetOffNuke(Pos{i, j})
+					continue
+				}
+
+				// Multi blocks take on the color of the block they land on, unless they stay wild - on the floor or on a special block they take on a color of the current level
+				if b.BlockColors[j][i] == Multi && b.Rules.WildcardMatches == false {
+					if j+1 > b.Height-1 || b.BlockColors[j+1][i].Special() == true {
+						b.BlockColors[j][i] = Color(b.Rand.Intn(b.CurrentLevel().Colors))
+					} else {
+						b.BlockColors[j][i] = b.BlockColors[j+1][i]
+					}
+				}
+
+				// Groups are found in any shape, so the straight line checks are not needed
+				if b.Rules.Mode == GroupMatches {
+					b.CheckGroupScore(Pos{i, j})
+					continue
+				}
+
+				b.CheckScore("down", Pos{i, j}, Pos{i, j + 1})
+				b.HandleScoreBlocks()
+
+				b.CheckScore("left", Pos{i, j}, Pos{i - 1, j})
+				b.HandleScoreBlocks()
+
+				b.CheckScore("right", Pos{i, j}, Pos{i + 1, j})
+				b.HandleScoreBlocks()
+
+				if b.Rules.Diagonals == true {
+					b.CheckScore("up_left", Pos{i, j}, Pos{i - 1, j - 1})
+					b.HandleScoreBlocks()
+
+					b.CheckScore("up_right", Pos{i, j}, Pos{i + 1, j - 1})
+					b.HandleScoreBlocks()
+
+					b.CheckScore("down_left", Pos{i, j}, Pos{i - 1, j + 1})
+					b.HandleScoreBlocks()
+
+					b.CheckScore("down_right", Pos{i, j}, Pos{i + 1, j + 1})
+					b.HandleScoreBlocks()
+				}
+			}
+		}
+	}
+
+	b.ScoreStep()
+
+	// Check whether a puzzle has been solved or has run out of pieces
+	b.CheckPuzzle()
+
+	// Check for falling blocks
+	if b.BlocksFalling == 0 {
+		for j := range b.BlockStates {
+			for i := range b.BlockStates[j] {
+				if b.BlockStates[j][i] == Inactive && (j+1 < b.Height) && (b.BlockStates[j+1][i] == Empty) {
+					b.BlocksFalling++
+				}
+			}
+		}
+	}
+
+	// Update falling blocks and the ones below them
+	if b.BlocksFalling > 0 && b.BlockFallingTimer >= b.BlockFallingTime {
+		for j := range b.BlockStates {
+			for i := range b.BlockStates[j] {
+				if b.BlockStates[j][i] == Inactive && (j+1 < b.Height) && (b.BlockStates[j+1][i] == Empty) {
+					b.BlockStates[j][i] = Empty
+
+					b.BlockStates[j+1][i] = Inactive
+					b.BlockColors[j+1][i] = b.BlockColors[j][i]
+
+					b.BlocksFalling--
+					b.BlockFallingTimer = 0
+				}
+			}
+		}
+	} else if b.BlocksFalling > 0 && b.BlockFallingTimer < b.BlockFallingTime {
+		b.BlockFallingTimer += time
+	}
+
+	b.BlocksFalling = 0
+
+	// Update the block falling timer
+	if b.BlockScorePausing == true && b.BlocksFallingTimer >= b.BlocksFallingTime {
+		b.BlockScorePausing = false
+		b.BlocksFallingTimer = 0
+	} else if b.BlockScorePausing == true && b.BlocksFallingTimer < b.BlocksFallingTime {
+		b.BlocksFallingTimer += time
+	}
+
+	// Push a row of garbage up from the bottom in modes that have it - done before spawning so that a new block never appears in a full top row
+	b.UpdateRise(time)
+
+	// Spawn a new current block for each player at the top of their part of the play area only once all other checks are complete
+	for k := range b.Pieces {
+		spawn := b.SpawnColumn(k)
+		if b.BlocksFalling == 0 &&
+			b.BlockScorePausing == false &&
+			b.HasPiece(k) == false &&
+			b.BlockStates[0][spawn] == Empty &&
+			b.PiecesLeft() != 0 {
+
+			b.Pieces[k].HoldUsed = false
+
+			// The chain is over once everything has settled and a new block comes in - in a versus match it is sent to the opponent as garbage
+			b.SendGarbage()
+			b.Chain = 0
+			b.Combo = 0
+
+			// Garbage sent by the opponent drops in before the new block, which comes in once the garbage has fallen out of its way
+			if b.GarbageIn > 0 {
+				b.DropGarbage()
+			} else if b.PairMode == true && b.BlockStates[1][spawn] == Empty {
+				b.spawnPair(k, spawn)
+			} else {
+				// A single block is spawned when there is no room left for a pair
+				b.Pieces[k].Pos = Pos{spawn, 0}
+				b.BlockStates[0][spawn] = Active
+				b.BlockColors[0][spawn] = b.popQueue()
+
+				// Check if the block below the starting block is filled - ensure game over if it is
+				// A level with a single color has no other color to pick, so the block is only kept to the colors of the level
+				if b.BlockStates[1][spawn] != Empty {
+					for b.BlockColors[0][spawn] == Multi || (b.BlockColors[0][spawn] == b.BlockColors[1][spawn] && b.CurrentLevel().Colors > 1) || b.isLevelColor(b.BlockColors[0][spawn]) == false {
+						b.BlockColors[0][spawn] = Color(b.Rand.Intn(b.CurrentLevel().Colors))
+					}
+				}
+			}
+		}
+	}
+
+	// Change levels if level points are above the number of points to change the level
+	if b.LevelScoreValue >= b.MaxLevelScoreValue && b.LevelValue < b.MaxLevelValue {
+		b.LevelScoreValue -= b.MaxLevelScoreValue
+		b.LevelValue++
+		b.applyLevel()
+	}
+
+	// DeGray the board if DeGray value is 0 or less - Gray blocks turn into the colors of the current level
+	if b.DeGrayValue <= 0 {
+		for j := range b.BlockStates {
+			for i := range b.BlockStates[j] {
+				if b.BlockStates[j][i] != Empty && b.BlockColors[j][i] == Gray {
+					b.BlockColors[j][i] = Color(b.Rand.Intn(b.CurrentLevel().Colors))
+				}
+			}
+		}
+		b.DeGrayValue = b.MaxDeGrayValue
+	}
+
+	// Check whether the time limit or the block goal of the mode has been reached
+	b.CheckModeLimits()
+
+	b.Frame++
+}
@@ -0,0 +1,113 @@
+package boardmodel
+
+// GarbageForChain returns the number of Gray blocks a chain of 'chain' links sends to the opponent - nothing for a single clear, then 1, 3, 6, 10 and so on
+func GarbageForChain(chain int) int {
+	if chain < 2 {
+		return 0
+	}
+	return chain * (chain - 1) / 2
+}
+
+// SendGarbage turns the chain that has just ended into garbage for the opponent - garbage that is waiting to drop on this board is cancelled out first
+func (b *Board) SendGarbage() {
+	send := GarbageForChain(b.Chain)
+	if send > b.GarbageIn {
+		send -= b.GarbageIn
+		b.GarbageIn = 0
+	} else {
+		b.GarbageIn -= send
+		send = 0
+	}
+	b.GarbageOut += send
+}
+
+// DropGarbage puts as much of the waiting garbage as fits into the empty cells of the top row, in columns picked at random, for it to fall from there
+func (b *Board) DropGarbage() {
+	for _, i := range b.Rand.Perm(b.Width) {
+		if b.GarbageIn == 0 {
+			break
+		}
+		if b.BlockStates[0][i] == Empty {
+			b.BlockStates[0][i] = Inactive
+			b.BlockColors[0][i] = Gray
+			b.GarbageIn--
+		}
+	}
+}
+
+// Versus steps the boards of a match together so that garbage always reaches the opponent on the same frame - the match is over as soon as a board tops out
+// Sent holds the garbage each board sent on the last step
+type Versus struct {
+	Boards     []*Board
+	Sent       []int
+	FrameTimer float64
+	Over       bool
+	Winner     int
+}
+
+// NewVersus is a versus match constructor - the boards should have been started already
+func NewVersus(boards ...*Board) *Versus {
+
+	v := &Versus{}
+
+	v.Boards = boards
+	v.Sent = make([]int, len(boards))
+	v.Winner = -1
+
+	return v
+}
+
+// Update advances every board of the match by 'time' milliseconds in whole steps of FrameTime
+func (v *Versus) Update(time float64) {
+	v.FrameTimer += time
+	if v.FrameTimer > MaxFrameBacklog {
+		v.FrameTimer = MaxFrameBacklog
+	}
+
+	for v.FrameTimer >= FrameTime && v.Over == false {
+		v.FrameTimer -= FrameTime
+		v.Step()
+	}
+}
+
+// Step advances every board of the match by a single frame, passes the garbage each board sent on to the next one and checks whether a board has topped out
+func (v *Versus) Step() {
+	for _, b := range v.Boards {
+		b.Step()
+	}
+
+	for k, b := range v.Boards {
+		next := v.Boards[(k+1)%len(v.Boards)]
+		next.GarbageIn += b.GarbageOut
+		v.Sent[k] = b.GarbageOut
+		b.GarbageOut = 0
+	}
+
+	// The last board standing wins - if every board tops out on the same frame there is no winner
+	standing := make([]int, 0, len(v.Boards))
+	for k, b := range v.Boards {
+		if b.GameOver == false {
+			standing = append(standing, k)
+		}
+	}
+	if len(standing) < len(v.Boards) {
+		v.Over = true
+		if len(standing) == 1 {
+			v.Winner = standing[0]
+		}
+	}
+}
+
+// Frame returns the number of frames the match has been running for
+func (v *Versus) Frame() int {
+	return v.Boards[0].Frame
+}
+
+// Hash returns a checksum of every board of the match
+func (v *Versus) Hash() uint64 {
+	var h uint64
+	for _, b := range v.Boards {
+		h = h*31 + b.Hash()
+	}
+	return h
+}
@@ -0,0 +1,73 @@
+package boardmodel_test
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"testing"
+)
+
+func TestGarbageForChain(t *testing.T) {
+	expected := []int{0, 0, 1, 3, 6, 10, 15, 21}
+	for chain, garbage := range expected {
+		if got := boardmodel.GarbageForChain(chain); got != garbage {
+			t.Errorf("a chain of %d sends %d blocks, expected %d", chain, got, garbage)
+		}
+	}
+}
+
+func TestSendGarbageCancelsWaitingGarbage(t *testing.T) {
+	tests := []struct {
+		chain, in       int
+		wantIn, wantOut int
+	}{
+		{1, 2, 2, 0},
+		{3, 0, 0, 3},
+		{3, 2, 0, 1},
+		{3, 5, 2, 0},
+		{4, 6, 0, 0},
+	}
+
+	for _, test := range tests {
+		b := emptyBoard(1)
+		b.Chain = test.chain
+		b.GarbageIn = test.in
+
+		b.SendGarbage()
+
+		if b.GarbageIn != test.wantIn || b.GarbageOut != test.wantOut {
+			t.Errorf("a chain of %d with %d waiting left %d waiting and sent %d, expected %d and %d", test.chain, test.in, b.GarbageIn, b.GarbageOut, test.wantIn, test.wantOut)
+		}
+	}
+}
+
+func TestChainGarbageIsDelivered(t *testing.T) {
+	a := emptyBoard(1)
+	b := emptyBoard(2)
+	placeCascade(a)
+	v := boardmodel.NewVersus(a, b)
+
+	// The chain is sent once the next block comes in on the board that made it
+	stepped := 0
+	for ; stepped < 2000 && b.GarbageIn == 0; stepped++ {
+		v.Step()
+	}
+	if a.MaxChain != 2 || b.GarbageIn != boardmodel.GarbageForChain(2) || a.GarbageOut != 0 {
+		t.Fatalf("after a chain of %d the opponent is waiting for %d blocks with %d still to send, expected %d waiting", a.MaxChain, b.GarbageIn, a.GarbageOut, boardmodel.GarbageForChain(2))
+	}
+
+	// and drops in as a Gray block in the top row before the opponent's next block
+	for ; stepped < 10000 && b.GarbageIn > 0; stepped++ {
+		v.Step()
+	}
+	grays := 0
+	for i := 0; i < b.Width; i++ {
+		if b.BlockStates[0][i] == boardmodel.Inactive && b.BlockColors[0][i] == boardmodel.Gray {
+			grays++
+		}
+	}
+	if b.GarbageIn != 0 || grays != 1 {
+		t.Errorf("%d blocks still waiting and %d Gray blocks in the top row after %d frames, expected the one block dropped", b.GarbageIn, grays, stepped)
+	}
+	if v.Over == true {
+		t.Errorf("the match is over, expected both boards still standing")
+	}
+}
@@ -0,0 +1,63 @@
+// Soak has the computer play games of PuzzleBlock without drawing them, logs how each went and fails if any of them plays back differently from its recording
+// It only needs the board rules, so it runs without a display or an audio device - run it from the PuzzleBlock folder so that the level table is found
+package main
+
+import (
+	"flag"
+	"golang-games/PuzzleBlock/ai"
+	"golang-games/PuzzleBlock/boardmodel"
+	"log"
+	"time"
+)
+
+// soakFrames is the most frames a game lasts - ten minutes of play
+const soakFrames int = 120000
+
+func main() {
+
+	// Command line flags
+	games := flag.Int("games", 10, "the number of games the computer plays")
+	difficultyName := flag.String("cpu", "Hard", "the difficulty the computer plays at: Easy, Normal or Hard")
+	seed := flag.Int64("seed", 0, "the seed of the first game - each game after it uses the next seed, and 0 picks one from the clock")
+	width := flag.Int("width", 5, "the number of columns of the play area")
+	height := flag.Int("height", 10, "the number of rows of the play area")
+	pairs := flag.Bool("pairs", false, "play with falling pairs instead of single blocks")
+	levelsPath := flag.String("levels", "assets/levels.json", "the level table the games are played with")
+	rulesPath := flag.String("rules", "", "play with the match rules in a JSON file instead of the default ones")
+	flag.Parse()
+
+	if *seed == 0 {
+		*seed = time.Now().UTC().UnixNano()
+	}
+
+	difficulty, err := ai.FindDifficulty(*difficultyName)
+	if err != nil {
+		panic(err)
+	}
+
+	b := boardmodel.NewBoard(*width, *height, *seed)
+	b.PairMode = *pairs
+
+	levels, err := boardmodel.LoadLevels(*levelsPath)
+	if err != nil {
+		panic(err)
+	}
+	b.Levels = levels
+
+	if *rulesPath != "" {
+		rules, err := boardmodel.LoadRules(*rulesPath)
+		if err != nil {
+			panic(err)
+		}
+		b.Rules = rules
+	}
+
+	log.Printf("soak: %d games at %s from seed %d", *games, difficulty.Name, *seed)
+	results, err := ai.Soak(b, *games, difficulty, *seed, soakFrames)
+	for k, r := range results {
+		log.Printf("soak: game %d: score %d, level %d, %d blocks cleared in %.1fs", k+1, r.Score, r.Level, r.BlocksCleared, r.PlayTime/1000)
+	}
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,278 @@
+package coopscreen
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"golang-games/PuzzleBlock/font"
+	"golang-games/PuzzleBlock/gameboard"
+	"golang-games/PuzzleBlock/gamestate"
+	"golang-games/PuzzleBlock/gamestatetransition"
+	"golang-games/PuzzleBlock/guicontrols"
+	"golang-games/PuzzleBlock/musicplayer"
+	"golang-games/PuzzleBlock/soundplayer"
+	"golang-games/PuzzleBlock/sprite"
+	"golang-games/PuzzleBlock/texturedrawing"
+	"golang-games/PuzzleBlock/vec3"
+	"math/rand"
+	"strconv"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Layout of the shared gameboard - a play area twice as wide as the single player game, with narrower side panels
+const (
+	numAcross     = 23
+	numDown       = 10
+	playAreaStart = 5
+	playAreaEnd   = 17
+)
+
+// CoopScreen is a struct that contains all the sprite information for a two player co-op game, where both players drop blocks onto one wide gameboard and share its score
+type CoopScreen struct {
+	CurrentGameState *gamestatetransition.GameStateTransition
+	MouseState       *guicontrols.MouseState
+	MusicPlayer      *musicplayer.MusicPlayer
+	SoundPlayer      *soundplayer.SoundPlayer
+	GameBoard        *gameboard.GameBoard
+	SharedBoard      *gameboard.GameBoard
+	Paused           bool
+	WinWidth         int
+	WinHeight        int
+	Background       *sprite.Sprite
+	Overlay          *texturedrawing.SinglePixelTexture
+	TextFont         *font.TTFFont
+	PlayerTexts      []*font.TTFString
+	ResultText       *font.TTFString
+	PrevResult       string
+	ScoreText        *font.TTFString
+	PrevScore        int
+	RetryButton      *guicontrols.TextButton
+	MainMenuButton   *guicontrols.TextButton
+}
+
+// NewCoopScreen is a co-op screen constructor - the shared gameboard takes the rules, levels and block mode of 'mainboard' when a game starts
+func NewCoopScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetransition.GameStateTransition, mousestate *guicontrols.MouseState, mainboard *gameboard.GameBoard, musicplayer *musicplayer.MusicPlayer, soundplayer *soundplayer.SoundPlayer, renderer *sdl.Renderer) *CoopScreen {
+
+	c := &CoopScreen{}
+
+	c.CurrentGameState = gamestate
+
+	c.MouseState = mousestate
+
+	c.MusicPlayer = musicplayer
+
+	c.SoundPlayer = soundplayer
+
+	c.GameBoard = mainboard
+
+	c.WinWidth = winWidth
+	c.WinHeight = winHeight
+
+	// Set the background image
+	c.Background = sprite.NewSprite(
+		"assets/background.png",
+		vec3.Vector3{X: 0, Y: 0, Z: 0},
+		vec3.Vector3{X: 0, Y: 0, Z: 0},
+		1280,
+		720,
+		float64(winWidth)/1280,
+		float64(winHeight)/720,
+		1,
+		1,
+		0,
+		0,
+		true,
+		0,
+		false,
+		renderer)
+
+	// Set the translucent overlay that darkens the game underneath the menu
+	c.Overlay = texturedrawing.NewSinglePixelTexture(sdl.Color{R: 0, G: 0, B: 0, A: 160}, sdl.Rect{X: 0, Y: 0, W: int32(winWidth), H: int32(winHeight)}, renderer)
+
+	// Set the font for the text
+	c.TextFont = font.NewTTFFont("assets/FifteenTwenty-Bold.otf", winWidth, winHeight)
+
+	// Set the shared gameboard across the whole window
+	c.SharedBoard = gameboard.NewGameBoard(winWidth, winHeight, winDepth, 0, winWidth, gamestate, numAcross, numDown, playAreaStart, playAreaEnd, rand.Int63(), musicplayer, soundplayer, renderer)
+	c.SharedBoard.ShowBackground = false
+	c.SharedBoard.Players = 2
+
+	// Player 1 plays on the left half of the play area and player 2 on the right, so their names go under the side panel on their side
+	c.PlayerTexts = make([]*font.TTFString, c.SharedBoard.Players)
+	for k, i := range []int{1, playAreaEnd + 1} {
+		c.PlayerTexts[k] = font.NewTTFString("P"+strconv.Itoa(k+1),
+			font.FontLarge,
+			sdl.Color{R: 255, G: 255, B: 0, A: 255},
+			vec3.Vector3{X: c.SharedBoard.Blocks[9][i].MainSprite.Pos.X, Y: c.SharedBoard.Blocks[9][i].MainSprite.Pos.Y, Z: 0},
+			c.SharedBoard.TextFont,
+			renderer)
+	}
+
+	// Set the result text - it is filled in by Draw once the game is paused or over
+	c.ResultText = font.NewTTFString(" ",
+		font.FontTitle,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: 0, Y: float32(winHeight) * 0.15, Z: 0},
+		c.TextFont,
+		renderer)
+	c.PrevResult = " "
+
+	c.ScoreText = font.NewTTFString("Score: 0",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 0, A: 255},
+		vec3.Vector3{X: 0, Y: float32(winHeight) * 0.40, Z: 0},
+		c.TextFont,
+		renderer)
+	c.ScoreText.SetCenterX()
+	c.PrevScore = 0
+
+	c.RetryButton = guicontrols.NewTextButton(c.WinWidth,
+		c.WinHeight,
+		"   Retry   ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(c.WinHeight) * 0.55, Z: 0},
+		0.1,
+		100,
+		c.TextFont,
+		renderer)
+	c.RetryButton.SetCenterX()
+
+	c.MainMenuButton = guicontrols.NewTextButton(c.WinWidth,
+		c.WinHeight,
+		"  Main Menu  ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(c.WinHeight) * 0.70, Z: 0},
+		0.1,
+		100,
+		c.TextFont,
+		renderer)
+	c.MainMenuButton.SetCenterX()
+
+	c.Start()
+
+	return c
+}
+
+// Start starts a new game on the shared gameboard, under the rules, levels and block mode set on the options screen
+func (c *CoopScreen) Start() {
+	c.SharedBoard.PairMode = c.GameBoard.PairMode
+	c.SharedBoard.Rules = c.GameBoard.Rules
+	c.SharedBoard.Levels = c.GameBoard.Levels
+	c.SharedBoard.Mode = boardmodel.EndlessMode()
+	c.SharedBoard.NewGame()
+	c.Paused = false
+
+	// Forget clicks from the last time the menu was up
+	c.RetryButton.WasLeftClicked = false
+	c.MainMenuButton.WasLeftClicked = false
+}
+
+// MoveActiveBlock moves the piece of player 'k' - nothing moves while the game is paused or over
+func (c *CoopScreen) MoveActiveBlock(k int, d string) {
+	if c.Paused == false && c.SharedBoard.Board.GameOver == false {
+		c.SharedBoard.MovePiece(k, d)
+	}
+}
+
+// Pause freezes the game and brings up the menu - a game that is over or in the middle of a transition can not be paused
+func (c *CoopScreen) Pause() {
+	if c.SharedBoard.Board.GameOver == false && c.CurrentGameState.TransitioningUp == false && c.CurrentGameState.TransitioningDown == false {
+		c.Paused = true
+
+		// Forget clicks from the last time the menu was up
+		c.RetryButton.WasLeftClicked = false
+		c.MainMenuButton.WasLeftClicked = false
+	}
+}
+
+// Resume goes straight back to the paused game
+func (c *CoopScreen) Resume() {
+	c.Paused = false
+}
+
+// Result returns the text shown over the game while the menu is up
+func (c *CoopScreen) Result() string {
+	if c.SharedBoard.Board.GameOver == true {
+		return "Game Over"
+	}
+	return "Paused"
+}
+
+// Update updates all the objects on the co-op screen
+func (c *CoopScreen) Update(time float64) {
+
+	// The menu is up while the game is paused or over
+	if c.Paused == true || c.SharedBoard.Board.GameOver == true {
+		// Start a new game if the retry button is clicked
+		if c.RetryButton.WasLeftClicked == true && c.CurrentGameState.TransitioningUp == false {
+			c.Start()
+		}
+
+		// Return to the title screen if the main menu button is clicked
+		if c.MainMenuButton.WasLeftClicked == true && c.CurrentGameState.TransitioningUp == false {
+			c.MusicPlayer.FutureTune = 0
+			c.CurrentGameState.TransitioningUp = true
+			c.CurrentGameState.ToState = gamestate.TitleScreen
+		}
+
+		// Update the buttons
+		c.RetryButton.Update(c.MouseState, time)
+		c.MainMenuButton.Update(c.MouseState, time)
+	}
+
+	if c.Paused == true {
+		return
+	}
+
+	// Update the background image
+	c.Background.Update(time)
+
+	// Update the rules of the game - the explosions of the last blocks cleared play on once it is over
+	c.SharedBoard.Board.Update(time)
+	c.SharedBoard.UpdateSprites(time)
+}
+
+// Draw draws all the objects on the co-op screen
+func (c *CoopScreen) Draw(renderer *sdl.Renderer) {
+
+	// Draw the background
+	c.Background.Draw(renderer)
+
+	// Draw the gameboard
+	c.SharedBoard.Draw(renderer)
+	for k := range c.PlayerTexts {
+		c.PlayerTexts[k].Draw(renderer)
+	}
+
+	if c.Paused == false && c.SharedBoard.Board.GameOver == false {
+		return
+	}
+
+	// Draw the menu over the game
+	c.Overlay.Draw(renderer)
+
+	if c.Result() != c.PrevResult {
+		c.ResultText.ChangeStringTexture(c.Result(), font.FontTitle, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		c.ResultText.SetCenterX()
+		c.PrevResult = c.Result()
+	}
+	c.ResultText.Draw(renderer)
+
+	if c.SharedBoard.Board.ScoreValue != c.PrevScore {
+		c.ScoreText.ChangeStringTexture("Score: "+strconv.Itoa(c.SharedBoard.Board.ScoreValue), font.FontLarge, sdl.Color{R: 255, G: 255, B: 0, A: 255}, renderer)
+		c.ScoreText.SetCenterX()
+		c.PrevScore = c.SharedBoard.Board.ScoreValue
+	}
+	c.ScoreText.Draw(renderer)
+
+	// Draw the buttons
+	c.RetryButton.Draw(renderer)
+	c.MainMenuButton.Draw(renderer)
+}
@@ -0,0 +1,140 @@
+package demoscreen
+
+import (
+	"golang-games/PuzzleBlock/ai"
+	"golang-games/PuzzleBlock/boardmodel"
+	"golang-games/PuzzleBlock/font"
+	"golang-games/PuzzleBlock/gameboard"
+	"golang-games/PuzzleBlock/gamestate"
+	"golang-games/PuzzleBlock/gamestatetransition"
+	"golang-games/PuzzleBlock/guicontrols"
+	"golang-games/PuzzleBlock/musicplayer"
+	"golang-games/PuzzleBlock/soundplayer"
+	"golang-games/PuzzleBlock/vec3"
+	"math/rand"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// DemoScreen is a struct that contains all the sprite information for the demo shown when the title screen is left alone, where the computer plays a game by itself
+type DemoScreen struct {
+	CurrentGameState *gamestatetransition.GameStateTransition
+	MouseState       *guicontrols.MouseState
+	MusicPlayer      *musicplayer.MusicPlayer
+	SoundPlayer      *soundplayer.SoundPlayer
+	GameBoard        *gameboard.GameBoard
+	DemoBoard        *gameboard.GameBoard
+	Player           *ai.Player
+	DemoTime         float64
+	DemoTimer        float64
+	WinWidth         int
+	WinHeight        int
+	TextFont         *font.TTFFont
+	DemoText         *font.TTFString
+	HintText         *font.TTFString
+}
+
+// NewDemoScreen is a demo screen constructor - the demo is played under the rules, levels and block mode of 'mainboard'
+func NewDemoScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetransition.GameStateTransition, mousestate *guicontrols.MouseState, mainboard *gameboard.GameBoard, musicplayer *musicplayer.MusicPlayer, soundplayer *soundplayer.SoundPlayer, renderer *sdl.Renderer) *DemoScreen {
+
+	d := &DemoScreen{}
+
+	d.CurrentGameState = gamestate
+
+	d.MouseState = mousestate
+
+	d.MusicPlayer = musicplayer
+
+	d.SoundPlayer = soundplayer
+
+	d.GameBoard = mainboard
+
+	d.WinWidth = winWidth
+	d.WinHeight = winHeight
+
+	// Set the font for the text
+	d.TextFont = font.NewTTFFont("assets/FifteenTwenty-Bold.otf", winWidth, winHeight)
+
+	// Set a gameboard of its own for the demo, so that the game the player last played is left alone - it is laid out for the standard setup the same way the main gameboard is
+	setup := gameboard.StandardSetup()
+	numAcross, playAreaStart, playAreaEnd := gameboard.Layout(winWidth, winHeight, setup.Width, setup.Height)
+	d.DemoBoard = gameboard.NewGameBoard(winWidth, winHeight, winDepth, 0, winWidth, gamestate, numAcross, setup.Height, playAreaStart, playAreaEnd, rand.Int63(), musicplayer, soundplayer, renderer)
+	d.Player = ai.NewPlayer(d.DemoBoard.Board, d.DemoBoard, ai.Hard(), rand.Int63())
+
+	d.DemoTime = 60000
+	d.DemoTimer = 0
+
+	// Set the label drawn over the demo
+	d.DemoText = font.NewTTFString("DEMO",
+		font.FontTitle,
+		sdl.Color{R: 255, G: 255, B: 0, A: 255},
+		vec3.Vector3{X: 0, Y: float32(winHeight) * 0.05, Z: 0},
+		d.TextFont,
+		renderer)
+	d.DemoText.SetCenterX()
+
+	d.HintText = font.NewTTFString("Press any key or click to return",
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: 0, Y: float32(winHeight) * 0.92, Z: 0},
+		d.TextFont,
+		renderer)
+	d.HintText.SetCenterX()
+
+	return d
+}
+
+// Start starts a new demo game, under the rules, levels and block mode set on the options screen
+func (d *DemoScreen) Start() {
+	d.DemoBoard.PairMode = d.GameBoard.PairMode
+	d.DemoBoard.Rules = d.GameBoard.Rules
+	d.DemoBoard.Levels = d.GameBoard.Levels
+	d.DemoBoard.Mode = boardmodel.EndlessMode()
+	d.DemoBoard.NewGame()
+	d.Player.Reset()
+
+	// The demo is never saved, so its inputs are not kept
+	d.DemoBoard.Board.Recording = false
+	d.DemoTimer = 0
+}
+
+// Stop returns to the title screen
+func (d *DemoScreen) Stop() {
+	if d.CurrentGameState.TransitioningUp == false {
+		d.MusicPlayer.FutureTune = 0
+		d.CurrentGameState.TransitioningUp = true
+		d.CurrentGameState.ToState = gamestate.TitleScreen
+	}
+}
+
+// Update updates all the objects on the demo screen - a click, the end of the game or the end of the demo time returns to the title screen
+func (d *DemoScreen) Update(time float64) {
+	if d.MouseState.LeftButton == true && d.MouseState.PrevLeftButton == false {
+		d.Stop()
+	}
+
+	d.DemoTimer += time
+	if d.DemoTimer >= d.DemoTime {
+		d.Stop()
+	}
+
+	// The game is only played for show - it is never saved as a replay or a high score
+	if d.DemoBoard.Board.GameOver == false {
+		d.Player.Update(time)
+	} else {
+		d.Stop()
+	}
+	d.DemoBoard.Board.Update(time)
+	d.DemoBoard.UpdateSprites(time)
+}
+
+// Draw draws all the objects on the demo screen
+func (d *DemoScreen) Draw(renderer *sdl.Renderer) {
+
+	// Draw the gameboard
+	d.DemoBoard.Draw(renderer)
+
+	// Draw the text over it
+	d.DemoText.Draw(renderer)
+	d.HintText.Draw(renderer)
+}
@@ -209,3 +209,17 @@ func (s *TTFString) Draw(renderer *sdl.Renderer) {
 	renderer.Copy(s.StringBackTexture, nil, &sdl.Rect{X: int32(s.Pos.X) + int32(float64(s.Font.WinWidth)*0.003), Y: int32(s.Pos.Y) + int32(float64(s.Font.WinWidth)*0.003), W: w, H: h})
 	renderer.Copy(s.StringTexture, nil, &sdl.Rect{X: int32(s.Pos.X), Y: int32(s.Pos.Y), W: w, H: h})
 }
+
+// Destroy frees the textures of the text - the text can not be drawn after it
+func (s *TTFString) Destroy() {
+	s.StringTexture.Destroy()
+	s.StringBackTexture.Destroy()
+}
+
+// Close closes every size of the font - strings can not be made with it after it
+func (f *TTFFont) Close() {
+	f.FontSmall.Close()
+	f.FontMedium.Close()
+	f.FontLarge.Close()
+	f.FontTitle.Close()
+}
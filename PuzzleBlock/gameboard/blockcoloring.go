@@ -35,16 +35,36 @@ func (g *GameBoard) SetBlockColoring(i, j int) {
 		for k := range g.Blocks[j][i].ExplosionSprites {
 			g.Blocks[j][i].ExplosionSprites[k].MainSprite.SetColorAndAlpha(sdl.Color{R: 128, G: 0, B: 128, A: 128})
 		}
-	case 5: // GRAY
+	case 5: // WHITE
+		g.Blocks[j][i].MainSprite.SetColorAndAlpha(sdl.Color{R: 255, G: 255, B: 255, A: 128})
+		for k := range g.Blocks[j][i].ExplosionSprites {
+			g.Blocks[j][i].ExplosionSprites[k].MainSprite.SetColorAndAlpha(sdl.Color{R: 255, G: 255, B: 255, A: 128})
+		}
+	case 6: // GRAY
 		g.Blocks[j][i].MainSprite.SetColorAndAlpha(sdl.Color{R: 128, G: 128, B: 128, A: 255})
 		for k := range g.Blocks[j][i].ExplosionSprites {
 			g.Blocks[j][i].ExplosionSprites[k].MainSprite.SetColorAndAlpha(sdl.Color{R: 128, G: 128, B: 128, A: 128})
 		}
-	case 6: // MULTI
+	case 7: // MULTI
 		g.Blocks[j][i].MainSprite.SetColorAndAlpha(sdl.Color{R: uint8(rand.Intn(255)), G: uint8(rand.Intn(255)), B: uint8(rand.Intn(255)), A: 128})
 		for k := range g.Blocks[j][i].ExplosionSprites {
 			g.Blocks[j][i].ExplosionSprites[k].MainSprite.SetColorAndAlpha(sdl.Color{R: uint8(rand.Intn(255)), G: uint8(rand.Intn(255)), B: uint8(rand.Intn(255)), A: 128})
 		}
+	case 8: // BOMB
+		g.Blocks[j][i].MainSprite.SetColorAndAlpha(sdl.Color{R: 255, G: 255, B: 255, A: 255})
+		for k := range g.Blocks[j][i].ExplosionSprites {
+			g.Blocks[j][i].ExplosionSprites[k].MainSprite.SetColorAndAlpha(sdl.Color{R: 255, G: 128, B: 0, A: 128})
+		}
+	case 9: // LINE
+		g.Blocks[j][i].MainSprite.SetColorAndAlpha(sdl.Color{R: 255, G: 255, B: 255, A: 255})
+		for k := range g.Blocks[j][i].ExplosionSprites {
+			g.Blocks[j][i].ExplosionSprites[k].MainSprite.SetColorAndAlpha(sdl.Color{R: 0, G: 255, B: 255, A: 128})
+		}
+	case 10: // NUKE
+		g.Blocks[j][i].MainSprite.SetColorAndAlpha(sdl.Color{R: 255, G: 255, B: 255, A: 255})
+		for k := range g.Blocks[j][i].ExplosionSprites {
+			g.Blocks[j][i].ExplosionSprites[k].MainSprite.SetColorAndAlpha(sdl.Color{R: 128, G: 255, B: 0, A: 128})
+		}
 	default:
 		g.Blocks[j][i].MainSprite.SetColorAndAlpha(sdl.Color{R: 255, G: 255, B: 255, A: 128})
 		for k := range g.Blocks[j][i].ExplosionSprites {
@@ -1,42 +1,25 @@
 package gameboard
 
 import (
+	"golang-games/PuzzleBlock/boardmodel"
 	"golang-games/PuzzleBlock/font"
 	"golang-games/PuzzleBlock/gamestate"
 	"golang-games/PuzzleBlock/gamestatetransition"
 	"golang-games/PuzzleBlock/musicplayer"
+	"golang-games/PuzzleBlock/replay"
 	"golang-games/PuzzleBlock/soundplayer"
 	"golang-games/PuzzleBlock/sprite"
-	"math/rand"
+	"log"
 	"strconv"
 
 	"github.com/veandco/go-sdl2/sdl"
 )
 
-// Pos is a struct that holds X/ Y positions for the gameboard
-type Pos struct {
-	X, Y int
-}
-
 // FPos is a struct that holds X/ Y positions in floating point values
 type FPos struct {
 	X, Y float32
 }
 
-// BlockState denotes the states a block on the gameboard can be in
-type BlockState int
-
-const (
-	// Empty block is a block that is not being drawn
-	Empty BlockState = iota
-	// Active block is controlled by the player
-	Active
-	// Inactive is a block that is being drawn, but which is not controlled by the player
-	Inactive
-	// Exploding is a block that is in the process of exploding
-	Exploding
-)
-
 // ExplosionSprite contains the data needed for each fragment of an exploding block
 type ExplosionSprite struct {
 	MainSprite       *sprite.Sprite
@@ -52,58 +35,70 @@ type Block struct {
 	NumberOfExplosionFragments int
 }
 
-// GameBoard is a struct that contains all the sprite information for the game
+// GameBoard is a struct that contains all the sprite information for the game - the rules of the game live in Board
 type GameBoard struct {
 	CurrentGameState           *gamestatetransition.GameStateTransition
 	MusicPlayer                *musicplayer.MusicPlayer
 	SoundPlayer                *soundplayer.SoundPlayer
+	Renderer                   *sdl.Renderer
+	WinWidth, WinHeight        int
+	WinDepth                   int
+	AreaX, AreaWidth           int
+	Board                      *boardmodel.Board
+	LastResults                boardmodel.Results
 	Blocks                     [][]Block
 	Background                 *sprite.Sprite
-	LevelValue                 int
-	MaxLevelValue              int
 	PrevLevelValue             int
-	ScoreValue                 int
-	MaxScoreValue              int
 	PrevScoreValue             int
-	DeGrayValue                int
-	MaxDeGrayValue             int
 	PrevDeGrayValue            int
-	BlockPointValue            int
-	LevelScoreValue            int
-	MaxLevelScoreValue         int
 	NumAcross, NumDown         int
 	PlayAreaStart, PlayAreaEnd int
+	PreviewLength              int
+	PairMode                   bool
+	Players                    int
+	Rules                      boardmodel.Rules
+	Levels                     []boardmodel.Level
+	Mode                       boardmodel.Mode
+	Setup                      Setup
+	Puzzles                    []*boardmodel.Puzzle
+	PuzzleIndex                int
+	PrevPuzzle                 *boardmodel.Puzzle
+	GhostSprites               []*sprite.Sprite
+	ShowGhost                  bool
+	HintSprites                []*sprite.Sprite
+	Hint                       []boardmodel.Pos
+	HintPiece                  int
+	MaxHints                   int
+	HintsLeft                  int
+	ShowBackground             bool
 	ColorR                     int
 	ColorG                     int
 	ColorB                     int
 	ColorTimer                 float64
-	BlockStates                [][]BlockState
-	CurrentActive              Pos
 	TextFont                   *font.TTFFont
 	LevelText                  *font.TTFString
 	LevelValueText             *font.TTFString
 	ScoreText                  *font.TTFString
 	ScoreValueText             *font.TTFString
+	HoldText                   *font.TTFString
 	NextText                   *font.TTFString
 	DeGrayText                 *font.TTFString
 	DeGrayValueText            *font.TTFString
-	LevelFall                  bool
-	LevelFallingTime           float64
-	LevelFallingTimer          float64
-	LevelPostFallTime          float64
-	LevelPostFallTimer         float64
-	BlocksFalling              int
-	BlockFallingTime           float64
-	BlockFallingTimer          float64
-	BlocksFallingTime          float64
-	BlocksFallingTimer         float64
-	GameOverTime               float64
-	GameOverTimer              float64
-	GameOverPausing            bool
-	BlockScorePausing          bool
-	BlocksForScore             int
+	GoalText                   *font.TTFString
+	ClockText                  *font.TTFString
+	PrevClock                  string
+	BlocksLeftText             *font.TTFString
+	PrevBlocksLeft             int
+	ChainText                  *font.TTFString
+	PrevChain                  int
+	ChainFlash                 string
+	PrevChainFlash             string
+	ChainFlashTimer            float64
 }
 
+// ChainFlashTime is how many milliseconds a chain or combo stays on the screen
+const ChainFlashTime = 1000.0
+
 // GameBoardToBlockStates translates an x coordinate in the play area to an x coordinate in the block states slice
 func (g *GameBoard) GameBoardToBlockStates(i int) int {
 	return i - g.PlayAreaStart - 1
@@ -114,144 +109,218 @@ func (g *GameBoard) BlockStatesToGameBoard(i int) int {
 	return i + g.PlayAreaStart
 }
 
-// Update updates all the tiles in the gameboard
-func (g *GameBoard) Update(time float64) {
+// NewGame clears the gameboard and starts recording a new game with the current settings
+func (g *GameBoard) NewGame() {
+	g.NewGameFromSeed(g.Board.Rand.Int63())
+}
 
-	// Update the background image
-	g.Background.Update(time)
+// NewGameFromSeed clears the gameboard and starts recording a new game with the current settings from 'seed' - gameboards started from the same seed are dealt the same blocks
+func (g *GameBoard) NewGameFromSeed(seed int64) {
+	g.Board.PairMode = g.PairMode
+	g.Board.Rules = g.Rules
+	g.Board.Levels = g.Setup.ApplyLevels(g.Levels)
+	g.Board.StartLevel = g.Setup.StartLevel
+	g.Board.Mode = g.Mode
+	g.Board.SetPlayers(g.Players)
+	g.Board.StartRecording(seed)
+	g.HintsLeft = g.MaxHints
+	g.Hint = nil
+}
+
+// StartPuzzle clears the gameboard and starts recording the k-th puzzle - puzzles are played on the standard gameboard
+func (g *GameBoard) StartPuzzle(k int) {
+	g.Setup = StandardSetup()
+	g.Resize(g.Setup.Width, g.Setup.Height)
+	g.PuzzleIndex = k
+	g.NewGame()
 
-	// Move the current block down at a rate equal to the games current level
-	if g.LevelFall == false && g.LevelFallingTimer >= g.LevelFallingTime {
-		g.MoveActiveBlock("down")
-		g.LevelFall = true
-		g.LevelFallingTimer = 0
-	} else if g.LevelFall == false && g.LevelFallingTimer < g.LevelFallingTime {
-		g.LevelFallingTimer += time + (float64(g.LevelValue-1) * time)
+	err := g.Board.SetPuzzle(g.Puzzles[k])
+	if err != nil {
+		panic(err)
 	}
+}
+
+// Restart starts the current game over - a puzzle starts over from its own layout
+func (g *GameBoard) Restart() {
+	if g.Board.Puzzle != nil {
+		g.StartPuzzle(g.PuzzleIndex)
+	} else {
+		g.NewGame()
+	}
+}
 
-	// Make sure there is a 'time buffer' between the last time we pressed 'down' and the next time the active block automatically falls
-	if g.LevelFall == true && g.LevelPostFallTimer >= g.LevelPostFallTime {
-		g.LevelFall = false
-		g.LevelPostFallTime = float64(g.MaxLevelValue*(g.MaxLevelValue-g.LevelValue)) + 1
-		g.LevelPostFallTimer = 0
-	} else if g.LevelFall == true && g.LevelPostFallTimer < g.LevelPostFallTime {
-		g.LevelPostFallTimer += time
+// Clock returns the time shown in timed modes - the time left when there is a time limit, the time played otherwise
+func (g *GameBoard) Clock() string {
+	if g.Board.Mode.TimeLimit > 0 {
+		return "Time: " + boardmodel.FormatTime(g.Board.TimeLeft())
 	}
+	return "Time: " + boardmodel.FormatTime(g.Board.PlayTime())
+}
 
-	// Stop the downward descent of the current block
-	if g.CurrentActive.Y == g.NumDown-1 || ((g.CurrentActive.X != -1 && g.CurrentActive.Y != -1) && g.BlockStates[g.CurrentActive.Y+1][g.CurrentActive.X] == Inactive) {
-		g.BlockStates[g.CurrentActive.Y][g.CurrentActive.X] = Inactive
-		g.CurrentActive = Pos{-1, -1}
+// LevelPanelValue returns the number shown under the level heading - the number of pieces left in a puzzle, the level otherwise
+func (g *GameBoard) LevelPanelValue() int {
+	if g.Board.Puzzle != nil {
+		return g.Board.PiecesLeft()
 	}
+	return g.Board.LevelValue
+}
+
+// QueueBlockPos returns the gameboard position of the preview of the k-th upcoming block - the previews are stacked down the right hand side of the gameboard
+func (g *GameBoard) QueueBlockPos(k int) (int, int) {
+	return (g.NumAcross + g.PlayAreaEnd) / 2, 2 + k
+}
 
-	// Check for game over state which occurs when one column of blocks reaches the top of the gameboard
-	currentYCount := make([]int, g.PlayAreaEnd-g.PlayAreaStart)
-	for j := range g.BlockStates {
-		for i := range g.BlockStates[j] {
-			if g.BlockStates[j][i] == Inactive {
-				currentYCount[i]++
+// HoldBlockPos returns the gameboard position of the 'hold' block
+func (g *GameBoard) HoldBlockPos() (int, int) {
+	return g.PlayAreaStart / 2, 8
+}
+
+// SyncBlocks copies the block states and colors of the board onto the sprites of the play area
+func (g *GameBoard) SyncBlocks() {
+	for j := range g.Board.BlockStates {
+		for i := range g.Board.BlockStates[j] {
+			blockSprite := g.Blocks[j][g.BlockStatesToGameBoard(i)].MainSprite
+			drawing := g.Board.IsFilled(i, j)
+			if drawing == true && (blockSprite.Drawing == false || blockSprite.CSequence != int(g.Board.BlockColors[j][i])) {
+				blockSprite.CSequence = int(g.Board.BlockColors[j][i])
+				g.SetBlockColoring(g.BlockStatesToGameBoard(i), j)
 			}
+			blockSprite.Drawing = drawing
 		}
 	}
 
-	// If a column of blocks reaches the top of the gameboard, reset everything for now
-	// TODO: add 'game over' state/ screen and transition to that instead of resetting everything
-	for k := range currentYCount {
-		if currentYCount[k] >= g.NumDown {
-			g.GameOverPausing = true
-			if g.GameOverTimer >= g.GameOverTime {
-				for j := range g.BlockStates {
-					for i := range g.BlockStates[j] {
-						g.BlockStates[j][i] = Empty
-						g.Blocks[j][g.BlockStatesToGameBoard(i)].MainSprite.Drawing = false
-					}
-				}
-				g.CurrentActive = Pos{-1, -1}
-				g.GameOverTimer = 0
-				g.GameOverPausing = false
-				g.LevelValue = 1
-				g.ScoreValue = 0
-				g.DeGrayValue = 10
-				g.LevelFallingTime = float64(g.MaxLevelValue * 100)
-				g.LevelFallingTimer = 0
-				g.LevelPostFallTimer = 0
-				g.BlockFallingTimer = 0
-				g.BlocksFallingTimer = 0
-
-				// Change the game state
-				g.MusicPlayer.FutureTune = 0
-				g.CurrentGameState.TransitioningUp = true
-				g.CurrentGameState.ToState = gamestate.TitleScreen
-				break
-			} else {
-				g.GameOverTimer += time
-			}
+	// Show as many upcoming blocks as the preview length allows - a puzzle shows no more than the pieces it has left
+	for k := 0; k < boardmodel.MaxQueueLength; k++ {
+		queueX, queueY := g.QueueBlockPos(k)
+		g.SetPanelBlock(queueX, queueY, g.Board.Queue[k], k < g.PreviewLength && (g.Board.Puzzle == nil || k < g.Board.PiecesLeft()))
+	}
+
+	holdX, holdY := g.HoldBlockPos()
+	g.SetPanelBlock(holdX, holdY, g.Board.HoldColor, g.Board.Holding)
+}
+
+// SyncGhost places a faint copy of each active block where it will land, unless the ghost is switched off
+func (g *GameBoard) SyncGhost() {
+	for k := range g.GhostSprites {
+		g.GhostSprites[k].Drawing = false
+	}
+
+	if g.ShowGhost == false {
+		return
+	}
+
+	active := g.Board.ActiveBlocks()
+	ghosts := g.Board.GhostBlocks()
+	for k := range ghosts {
+		// There is nothing to show once a block is already resting where it will land
+		if k >= len(g.GhostSprites) || ghosts[k] == active[k] {
+			continue
 		}
+		g.GhostSprites[k].Pos = g.Blocks[ghosts[k].Y][g.BlockStatesToGameBoard(ghosts[k].X)].MainSprite.Pos
+		g.GhostSprites[k].CSequence = int(g.Board.BlockColors[active[k].Y][active[k].X])
+		g.GhostSprites[k].Drawing = true
 	}
-	currentYCount = nil
+}
 
-	// Update the colors of the multi-blocks
-	g.ColorTimer += time
-	if g.ColorTimer >= 50 {
-		for j := 0; j < g.NumDown; j++ {
-			for i := g.PlayAreaStart; i < g.PlayAreaEnd; i++ {
-				if g.Blocks[j][i].MainSprite.Drawing == true && g.Blocks[j][i].MainSprite.CSequence == 6 {
-					g.UpdateMultiBlockColor(i, j)
-				}
+// SetPanelBlock shows a block of 'color' in a cell of the side panels, or turns the cell back into part of the panel when 'showing' is false
+func (g *GameBoard) SetPanelBlock(i, j int, color boardmodel.Color, showing bool) {
+	panelSprite := g.Blocks[j][i].MainSprite
+	if showing == true && (panelSprite.Animating == false || panelSprite.CSequence != int(color)) {
+		panelSprite.CSequence = int(color)
+		g.SetBlockColoring(i, j)
+		panelSprite.Animating = true
+	} else if showing == false && panelSprite.Animating == true {
+		panelSprite.CSequence = int(boardmodel.Gray)
+		panelSprite.SetColorAndAlpha(sdl.Color{R: 255, G: 255, B: 255, A: 64})
+		panelSprite.Animating = false
+	}
+}
+
+// Update updates all the tiles in the gameboard
+func (g *GameBoard) Update(time float64) {
+
+	// Update the rules of the game
+	g.Board.Update(time)
+
+	// If a column of blocks reaches the top of the gameboard, show the game over screen
+	if g.Board.GameOver == true && g.CurrentGameState.TransitioningUp == false {
+		// Keep a replay of the game that just ended
+		if g.Board.Recording == true {
+			_, err := replay.NewReplay(g.Board).SaveToUserData()
+			if err != nil {
+				log.Println(err)
 			}
+			g.Board.Recording = false
 		}
-		if g.Blocks[2][(g.NumAcross+g.PlayAreaEnd)/2].MainSprite.CSequence == 6 {
-			g.UpdateMultiBlockColor((g.NumAcross+g.PlayAreaEnd)/2, 2)
-		}
-		g.ColorTimer = 0
-	}
 
-	// Check for block scores
-	for j := range g.BlockStates {
-		for i := range g.BlockStates[j] {
-			if g.BlockStates[j][i] == Inactive && g.Blocks[j][g.BlockStatesToGameBoard(i)].MainSprite.Drawing == true {
-				if g.Blocks[j][g.BlockStatesToGameBoard(i)].MainSprite.CSequence == 6 {
-					if j+1 > g.NumDown-1 {
-						for g.Blocks[j][g.BlockStatesToGameBoard(i)].MainSprite.CSequence == 6 {
-							g.Blocks[j][g.BlockStatesToGameBoard(i)].MainSprite.CSequence = rand.Intn(7)
-							g.SetBlockColoring(g.BlockStatesToGameBoard(i), j)
-						}
-					} else {
-						g.Blocks[j][g.BlockStatesToGameBoard(i)].MainSprite.CSequence = g.Blocks[j+1][g.BlockStatesToGameBoard(i)].MainSprite.CSequence
-						g.SetBlockColoring(g.BlockStatesToGameBoard(i), j)
-					}
+		g.LastResults = g.Board.Results()
 
-				}
+		// Change the game state - a puzzle shows whether it was solved instead of the results
+		g.MusicPlayer.FutureTune = 0
+		g.CurrentGameState.TransitioningUp = true
+		if g.Board.Puzzle != nil {
+			g.CurrentGameState.ToState = gamestate.PuzzleResult
+		} else {
+			g.CurrentGameState.ToState = gamestate.GameOver
+		}
+	}
 
-				g.CheckScore("down", Pos{i, j}, Pos{i, j + 1})
-				//fmt.Println("down g.BlocksForScore: ", g.BlocksForScore)
-				g.HandleScoreBlocks()
+	g.UpdateSprites(time)
+}
 
-				g.CheckScore("left", Pos{i, j}, Pos{i - 1, j})
-				//fmt.Println("left g.BlocksForScore: ", g.BlocksForScore)
-				g.HandleScoreBlocks()
+// UpdateSprites brings the sprites up to date with a board that has just been updated and moves the animations on by 'time' milliseconds
+func (g *GameBoard) UpdateSprites(time float64) {
 
-				g.CheckScore("right", Pos{i, j}, Pos{i + 1, j})
-				//fmt.Println("right g.BlocksForScore: ", g.BlocksForScore)
-				g.HandleScoreBlocks()
+	// Update the background image
+	g.Background.Update(time)
 
-				g.CheckScore("up_left", Pos{i, j}, Pos{i - 1, j - 1})
-				//fmt.Println("up_left g.BlocksForScore: ", g.BlocksForScore)
-				g.HandleScoreBlocks()
+	// Start the explosions of any blocks the board cleared
+	g.HandleClearedBlocks()
+
+	// Flash the chain over the play area whenever a link is added to it
+	if g.Board.Chain != g.PrevChain {
+		if g.Board.Chain >= 2 {
+			g.ChainFlash = "Chain x" + strconv.Itoa(g.Board.Chain)
+			g.ChainFlashTimer = ChainFlashTime
+		} else if g.Board.Chain == 1 && g.Board.Combo >= 2 {
+			g.ChainFlash = "Combo x" + strconv.Itoa(g.Board.Combo)
+			g.ChainFlashTimer = ChainFlashTime
+		}
+		g.PrevChain = g.Board.Chain
+	}
 
-				g.CheckScore("up_right", Pos{i, j}, Pos{i + 1, j - 1})
-				//fmt.Println("up_right g.BlocksForScore: ", g.BlocksForScore)
-				g.HandleScoreBlocks()
+	// A special block going off takes over the flash
+	g.HandleSpecials()
+	if g.ChainFlashTimer > 0 {
+		g.ChainFlashTimer -= time
+	}
 
-				g.CheckScore("down_left", Pos{i, j}, Pos{i - 1, j + 1})
-				//fmt.Println("down_left g.BlocksForScore: ", g.BlocksForScore)
-				g.HandleScoreBlocks()
+	// Copy the board onto the sprites
+	g.SyncBlocks()
+	g.SyncGhost()
+	g.SyncHint()
 
-				g.CheckScore("down_right", Pos{i, j}, Pos{i + 1, j + 1})
-				//fmt.Println("down_right g.BlocksForScore: ", g.BlocksForScore)
-				g.HandleScoreBlocks()
+	// Update the colors of the multi-blocks
+	g.ColorTimer += time
+	if g.ColorTimer >= 50 {
+		for j := 0; j < g.NumDown; j++ {
+			for i := g.PlayAreaStart; i < g.PlayAreaEnd; i++ {
+				if g.Blocks[j][i].MainSprite.Drawing == true && g.Blocks[j][i].MainSprite.CSequence == int(boardmodel.Multi) {
+					g.UpdateMultiBlockColor(i, j)
+				}
+			}
+		}
+		for k := 0; k < g.PreviewLength; k++ {
+			queueX, queueY := g.QueueBlockPos(k)
+			if g.Blocks[queueY][queueX].MainSprite.CSequence == int(boardmodel.Multi) {
+				g.UpdateMultiBlockColor(queueX, queueY)
 			}
 		}
+		holdX, holdY := g.HoldBlockPos()
+		if g.Board.Holding == true && g.Blocks[holdY][holdX].MainSprite.CSequence == int(boardmodel.Multi) {
+			g.UpdateMultiBlockColor(holdX, holdY)
+		}
+		g.ColorTimer = 0
 	}
 
 	// Update explosion sprite lifespans and reset once they are done
@@ -275,89 +344,6 @@ func (g *GameBoard) Update(time float64) {
 		}
 	}
 
-	// Check for falling blocks
-	if g.BlocksFalling == 0 {
-		for j := range g.BlockStates {
-			for i := range g.BlockStates[j] {
-				if g.BlockStates[j][i] == Inactive &&
-					g.Blocks[j][g.BlockStatesToGameBoard(i)].MainSprite.Drawing == true &&
-					(j+1 < g.NumDown) && (g.BlockStates[j+1][i] == Empty) &&
-					(g.Blocks[j+1][g.BlockStatesToGameBoard(i)].MainSprite.Drawing == false) {
-
-					g.BlocksFalling++
-					//g.BlockFallPausing = true
-				}
-			}
-		}
-	}
-
-	// Update falling blocks and the ones below them
-	if g.BlocksFalling > 0 && g.BlockFallingTimer >= g.BlockFallingTime {
-		for j := range g.BlockStates {
-			for i := range g.BlockStates[j] {
-				if g.BlockStates[j][i] == Inactive && g.Blocks[j][g.BlockStatesToGameBoard(i)].MainSprite.Drawing == true {
-					if (j+1 < g.NumDown) && (g.BlockStates[j+1][i] == Empty) && (g.Blocks[j+1][g.BlockStatesToGameBoard(i)].MainSprite.Drawing == false) {
-						g.BlockStates[j][i] = Empty
-						g.Blocks[j][g.BlockStatesToGameBoard(i)].MainSprite.Drawing = false
-
-						g.BlockStates[j+1][i] = Inactive
-						g.Blocks[j+1][g.BlockStatesToGameBoard(i)].MainSprite.CSequence = g.Blocks[j][g.BlockStatesToGameBoard(i)].MainSprite.CSequence
-						g.SetBlockColoring(g.BlockStatesToGameBoard(i), j+1)
-						g.Blocks[j+1][g.BlockStatesToGameBoard(i)].MainSprite.Drawing = true
-
-						g.BlocksFalling--
-						g.BlockFallingTimer = 0
-					}
-				}
-			}
-		}
-	} else if g.BlocksFalling > 0 && g.BlockFallingTimer < g.BlockFallingTime {
-		g.BlockFallingTimer += time
-	}
-
-	g.BlocksFalling = 0
-
-	// Update the block falling timer
-	if g.BlockScorePausing == true && g.BlocksFallingTimer >= g.BlocksFallingTime {
-		g.BlockScorePausing = false
-		g.BlocksFallingTimer = 0
-	} else if g.BlockScorePausing == true && g.BlocksFallingTimer < g.BlocksFallingTime {
-		g.BlocksFallingTimer += time
-	}
-
-	// Make sure empty blocks are empty
-	for j := range g.BlockStates {
-		for i := range g.BlockStates[j] {
-			if g.Blocks[j][g.BlockStatesToGameBoard(i)].MainSprite.Drawing == false {
-				g.BlockStates[j][i] = Empty
-			}
-		}
-	}
-
-	// Spawn a new current block at the top of the play area only once all other checks are complete
-	if g.BlocksFalling == 0 &&
-		g.BlockScorePausing == false &&
-		(g.CurrentActive.X == -1 && g.CurrentActive.Y == -1) &&
-		g.Blocks[0][(g.PlayAreaStart+g.PlayAreaEnd)/2].MainSprite.Drawing == false {
-
-		g.CurrentActive = Pos{2, 0}
-		g.BlockStates[0][2] = Active
-		g.Blocks[0][(g.PlayAreaStart+g.PlayAreaEnd)/2].MainSprite.CSequence = g.Blocks[2][(g.NumAcross+g.PlayAreaEnd)/2].MainSprite.CSequence
-		g.SetBlockColoring((g.PlayAreaStart+g.PlayAreaEnd)/2, 0)
-		g.Blocks[2][(g.NumAcross+g.PlayAreaEnd)/2].MainSprite.CSequence = rand.Intn(7)
-		g.SetBlockColoring((g.NumAcross+g.PlayAreaEnd)/2, 2)
-
-		// Check if the block below the starting block is being drawn - ensure game over if it is
-		if g.Blocks[1][(g.PlayAreaStart+g.PlayAreaEnd)/2].MainSprite.Drawing == true {
-			for g.Blocks[0][(g.PlayAreaStart+g.PlayAreaEnd)/2].MainSprite.CSequence == 6 || g.Blocks[0][(g.PlayAreaStart+g.PlayAreaEnd)/2].MainSprite.CSequence == g.Blocks[1][(g.PlayAreaStart+g.PlayAreaEnd)/2].MainSprite.CSequence {
-				g.Blocks[0][(g.PlayAreaStart+g.PlayAreaEnd)/2].MainSprite.CSequence = rand.Intn(6)
-			}
-		}
-
-		g.SetBlockColoring((g.PlayAreaStart+g.PlayAreaEnd)/2, 0)
-		g.Blocks[0][(g.PlayAreaStart+g.PlayAreaEnd)/2].MainSprite.Drawing = true
-	}
-
 	// Update all the blocks
 	for j := range g.Blocks {
 		for i := range g.Blocks[j] {
@@ -365,6 +351,16 @@ func (g *GameBoard) Update(time float64) {
 		}
 	}
 
+	// Update the ghost blocks
+	for k := range g.GhostSprites {
+		g.GhostSprites[k].Update(time)
+	}
+
+	// Update the hint blocks
+	for k := range g.HintSprites {
+		g.HintSprites[k].Update(time)
+	}
+
 	// Update explosion fragments
 	for j := range g.Blocks {
 		for i := range g.Blocks[j] {
@@ -373,32 +369,15 @@ func (g *GameBoard) Update(time float64) {
 			}
 		}
 	}
-
-	// Update text - change levels if level points are above the number of points to change the level
-	if g.LevelScoreValue >= g.MaxLevelScoreValue && g.LevelValue < g.MaxLevelValue {
-		g.LevelScoreValue -= g.MaxLevelScoreValue
-		g.LevelValue++
-	}
-
-	// Update text - DeGray the level if DeGray value is 0 or less
-	if g.DeGrayValue <= 0 {
-		for j := range g.BlockStates {
-			for i := range g.BlockStates[j] {
-				if g.Blocks[j][g.BlockStatesToGameBoard(i)].MainSprite.CSequence == 5 {
-					g.Blocks[j][g.BlockStatesToGameBoard(i)].MainSprite.CSequence = rand.Intn(5)
-					g.SetBlockColoring(g.BlockStatesToGameBoard(i), j)
-				}
-			}
-		}
-		g.DeGrayValue = g.MaxDeGrayValue
-	}
 }
 
 // Draw draws all the tiles in the gameboard
 func (g *GameBoard) Draw(renderer *sdl.Renderer) {
 
 	// Draw the background
-	g.Background.Draw(renderer)
+	if g.ShowBackground == true {
+		g.Background.Draw(renderer)
+	}
 
 	// Draw the blocks
 	for j := range g.Blocks {
@@ -407,6 +386,16 @@ func (g *GameBoard) Draw(renderer *sdl.Renderer) {
 		}
 	}
 
+	// Draw the ghost blocks
+	for k := range g.GhostSprites {
+		g.GhostSprites[k].Draw(renderer)
+	}
+
+	// Draw the hint blocks
+	for k := range g.HintSprites {
+		g.HintSprites[k].Draw(renderer)
+	}
+
 	// Draw the explosion sprites
 	for j := range g.Blocks {
 		for i := range g.Blocks[j] {
@@ -416,20 +405,55 @@ func (g *GameBoard) Draw(renderer *sdl.Renderer) {
 		}
 	}
 
+	// Show the pieces left and the goal in place of the level while a puzzle is being played
+	if g.Board.Puzzle != g.PrevPuzzle {
+		if g.Board.Puzzle != nil {
+			g.LevelText.ChangeStringTexture("Drops:", font.FontLarge, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+			g.GoalText.ChangeStringTexture("Goal: "+g.Board.Puzzle.Goal.String(), font.FontSmall, sdl.Color{R: 255, G: 255, B: 0, A: 255}, renderer)
+		} else {
+			g.LevelText.ChangeStringTexture("Level:", font.FontLarge, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		}
+		g.PrevPuzzle = g.Board.Puzzle
+	}
+
 	// Change the display text depending on whether the underlying value has changed
-	if g.LevelValue != g.PrevLevelValue {
-		g.LevelValueText.ChangeStringTexture(strconv.Itoa(g.LevelValue), font.FontLarge, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
-		g.PrevLevelValue = g.LevelValue
+	if g.LevelPanelValue() != g.PrevLevelValue {
+		g.LevelValueText.ChangeStringTexture(strconv.Itoa(g.LevelPanelValue()), font.FontLarge, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		g.PrevLevelValue = g.LevelPanelValue()
+	}
+
+	if g.Board.ScoreValue != g.PrevScoreValue {
+		g.ScoreValueText.ChangeStringTexture(strconv.Itoa(g.Board.ScoreValue), font.FontLarge, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		g.PrevScoreValue = g.Board.ScoreValue
+	}
+
+	if g.Board.DeGrayValue != g.PrevDeGrayValue {
+		g.DeGrayValueText.ChangeStringTexture(strconv.Itoa(g.Board.DeGrayValue), font.FontLarge, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		g.PrevDeGrayValue = g.Board.DeGrayValue
 	}
 
-	if g.ScoreValue != g.PrevScoreValue {
-		g.ScoreValueText.ChangeStringTexture(strconv.Itoa(g.ScoreValue), font.FontLarge, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
-		g.PrevScoreValue = g.ScoreValue
+	if g.Clock() != g.PrevClock {
+		g.ClockText.ChangeStringTexture(g.Clock(), font.FontMedium, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		g.PrevClock = g.Clock()
 	}
 
-	if g.DeGrayValue != g.PrevDeGrayValue {
-		g.DeGrayValueText.ChangeStringTexture(strconv.Itoa(g.DeGrayValue), font.FontLarge, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
-		g.PrevDeGrayValue = g.DeGrayValue
+	if g.Board.BlocksLeft() != g.PrevBlocksLeft {
+		g.BlocksLeftText.ChangeStringTexture("Left: "+strconv.Itoa(g.Board.BlocksLeft()), font.FontMedium, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		g.PrevBlocksLeft = g.Board.BlocksLeft()
+	}
+
+	if g.ChainFlash != g.PrevChainFlash {
+		g.ChainText.ChangeStringTexture(g.ChainFlash, font.FontLarge, sdl.Color{R: 255, G: 255, B: 0, A: 255}, renderer)
+		g.PrevChainFlash = g.ChainFlash
+
+		// Center the text over the play area
+		_, _, w, _, err := g.ChainText.StringTexture.Query()
+		if err != nil {
+			panic(err)
+		}
+		left := g.Blocks[0][g.PlayAreaStart].MainSprite.Pos.X
+		right := g.Blocks[0][g.PlayAreaEnd].MainSprite.Pos.X
+		g.ChainText.Pos.X = (left+right)/2 - float32(w)/2
 	}
 
 	// Draw the text
@@ -437,7 +461,20 @@ func (g *GameBoard) Draw(renderer *sdl.Renderer) {
 	g.LevelValueText.Draw(renderer)
 	g.ScoreText.Draw(renderer)
 	g.ScoreValueText.Draw(renderer)
+	g.HoldText.Draw(renderer)
 	g.NextText.Draw(renderer)
 	g.DeGrayText.Draw(renderer)
 	g.DeGrayValueText.Draw(renderer)
+	if g.Board.Puzzle != nil {
+		g.GoalText.Draw(renderer)
+	}
+	if g.Board.Mode.TimeLimit > 0 || g.Board.Mode.BlockGoal > 0 {
+		g.ClockText.Draw(renderer)
+	}
+	if g.Board.Mode.BlockGoal > 0 {
+		g.BlocksLeftText.Draw(renderer)
+	}
+	if g.ChainFlashTimer > 0 {
+		g.ChainText.Draw(renderer)
+	}
 }
@@ -0,0 +1,52 @@
+package gameboard
+
+import (
+	"golang-games/PuzzleBlock/ai"
+	"strconv"
+)
+
+// ShowHint highlights where the active block makes the biggest match straight away - only MaxHints hints are given each game, and one that finds no match is not used up
+func (g *GameBoard) ShowHint() {
+	if g.HintsLeft <= 0 {
+		g.flashHint("No hints left")
+		return
+	}
+
+	hint, found := ai.Hint(g.Board)
+	if found == false {
+		g.flashHint("No match")
+		return
+	}
+
+	g.Hint = hint
+	g.HintPiece = g.Board.PiecesUsed
+	g.HintsLeft--
+	g.flashHint("Hints left: " + strconv.Itoa(g.HintsLeft))
+}
+
+// flashHint shows 'text' over the play area in place of the chain for a moment
+func (g *GameBoard) flashHint(text string) {
+	g.ChainFlash = text
+	g.ChainFlashTimer = ChainFlashTime
+}
+
+// SyncHint places the hint blocks on the cells of the last hint - the hint goes away once the block it was for is no longer in play
+func (g *GameBoard) SyncHint() {
+	for k := range g.HintSprites {
+		g.HintSprites[k].Drawing = false
+	}
+
+	if g.Hint != nil && (g.Board.HasPiece(0) == false || g.Board.PiecesUsed != g.HintPiece) {
+		g.Hint = nil
+	}
+
+	active := g.Board.PieceBlocks(0)
+	for k := range g.Hint {
+		if k >= len(g.HintSprites) || k >= len(active) {
+			continue
+		}
+		g.HintSprites[k].Pos = g.Blocks[g.Hint[k].Y][g.BlockStatesToGameBoard(g.Hint[k].X)].MainSprite.Pos
+		g.HintSprites[k].CSequence = int(g.Board.BlockColors[active[k].Y][active[k].X])
+		g.HintSprites[k].Drawing = true
+	}
+}
@@ -1,6 +1,7 @@
 package gameboard
 
 import (
+	"golang-games/PuzzleBlock/boardmodel"
 	"golang-games/PuzzleBlock/font"
 	"golang-games/PuzzleBlock/gamestatetransition"
 	"golang-games/PuzzleBlock/musicplayer"
@@ -13,8 +14,9 @@ import (
 	"github.com/veandco/go-sdl2/sdl"
 )
 
-// NewGameBoard is a gameboard constructor
-func NewGameBoard(winWidth, winHeight, winDepth int, gamestate *gamestatetransition.GameStateTransition, numAcross, numDown, playAreaStart, playAreaEnd int, musicplayer *musicplayer.MusicPlayer, soundplayer *soundplayer.SoundPlayer, renderer *sdl.Renderer) *GameBoard {
+// NewGameBoard is a gameboard constructor - 'seed' seeds the random source that decides how the game plays out
+// The gameboard is laid out in the strip of the window that starts 'areaX' pixels from the left and is 'areaWidth' pixels wide, so that more than one can share the window
+func NewGameBoard(winWidth, winHeight, winDepth, areaX, areaWidth int, gamestate *gamestatetransition.GameStateTransition, numAcross, numDown, playAreaStart, playAreaEnd int, seed int64, musicplayer *musicplayer.MusicPlayer, soundplayer *soundplayer.SoundPlayer, renderer *sdl.Renderer) *GameBoard {
 
 	g := &GameBoard{}
 
@@ -24,6 +26,17 @@ func NewGameBoard(winWidth, winHeight, winDepth int, gamestate *gamestatetransit
 
 	g.SoundPlayer = soundplayer
 
+	// The window and the strip of it the gameboard is laid out in are kept so that it can be laid out again for another size of play area
+	g.Renderer = renderer
+	g.WinWidth = winWidth
+	g.WinHeight = winHeight
+	g.WinDepth = winDepth
+	g.AreaX = areaX
+	g.AreaWidth = areaWidth
+
+	g.Board = boardmodel.NewBoard(playAreaEnd-playAreaStart, numDown, seed)
+	g.Board.StartRecording(seed)
+
 	g.Blocks = make([][]Block, numDown)
 
 	// Set blocks and explosions
@@ -33,7 +46,7 @@ func NewGameBoard(winWidth, winHeight, winDepth int, gamestate *gamestatetransit
 			g.Blocks[j][i].MainSprite = sprite.NewSprite(
 				"assets/Gems.png",
 				vec3.Vector3{
-					X: (float32(i) * float32(64)) * (float32(winWidth) / float32(numAcross)) / float32(64),
+					X: float32(areaX) + (float32(i)*float32(64))*(float32(areaWidth)/float32(numAcross))/float32(64),
 					Y: (float32(j) * float32(64)) * (float32(winHeight) / float32(numDown)) / float32(64),
 					Z: float32(winDepth)},
 				vec3.Vector3{
@@ -42,10 +55,10 @@ func NewGameBoard(winWidth, winHeight, winDepth int, gamestate *gamestatetransit
 					Z: 0},
 				64,
 				64,
-				float64(winWidth/numAcross)/64,
+				float64(areaWidth/numAcross)/64,
 				float64(winHeight/numDown)/64,
 				10,
-				7,
+				11,
 				0,
 				6,
 				true,
@@ -59,12 +72,12 @@ func NewGameBoard(winWidth, winHeight, winDepth int, gamestate *gamestatetransit
 			for l := range g.Blocks[j][i].ExplosionSprites {
 
 				g.Blocks[j][i].ExplosionSprites[l].OriginalPosition = FPos{
-					X: ((float32(i) * float32(64)) * (float32(winWidth) / float32(numAcross)) / float32(64)) +
-						(float32(32) * (float32(winWidth/numAcross) / 64)) +
-						(float32(32-rand.Intn(64)-8) * (float32(winWidth/numAcross) / 64)),
+					X: float32(areaX) + ((float32(i) * float32(64)) * (float32(areaWidth) / float32(numAcross)) / float32(64)) +
+						(float32(32) * (float32(areaWidth/numAcross) / 64)) +
+						(float32(32-rand.Intn(64)-8) * (float32(areaWidth/numAcross) / 64)),
 					Y: (float32(j)*float32(64))*((float32(winHeight)/float32(numDown))/float32(64)) +
 						(float32(32) * (float32(winHeight/numDown) / 64)) +
-						(float32(32-rand.Intn(64)-8) * (float32(winWidth/numAcross) / 64))}
+						(float32(32-rand.Intn(64)-8) * (float32(areaWidth/numAcross) / 64))}
 
 				g.Blocks[j][i].ExplosionSprites[l].MainSprite = sprite.NewSprite("assets/Gem.png",
 					vec3.Vector3{
@@ -77,7 +90,7 @@ func NewGameBoard(winWidth, winHeight, winDepth int, gamestate *gamestatetransit
 						Z: 0},
 					16,
 					16,
-					float64(winWidth/numAcross)/64,
+					float64(areaWidth/numAcross)/64,
 					float64(winHeight/numDown)/64,
 					4,
 					4,
@@ -95,7 +108,7 @@ func NewGameBoard(winWidth, winHeight, winDepth int, gamestate *gamestatetransit
 	// Left hand side of gameboard
 	for j := 0; j < numDown; j++ {
 		for i := 0; i < playAreaStart; i++ {
-			g.Blocks[j][i].MainSprite.CSequence = 5
+			g.Blocks[j][i].MainSprite.CSequence = int(boardmodel.Gray)
 			g.Blocks[j][i].MainSprite.Animating = false
 			g.Blocks[j][i].MainSprite.CFrame = rand.Intn(10)
 		}
@@ -114,7 +127,7 @@ func NewGameBoard(winWidth, winHeight, winDepth int, gamestate *gamestatetransit
 	// Right hand side of gameboard
 	for j := 0; j < numDown; j++ {
 		for i := playAreaEnd; i < numAcross; i++ {
-			g.Blocks[j][i].MainSprite.CSequence = 5
+			g.Blocks[j][i].MainSprite.CSequence = int(boardmodel.Gray)
 			g.Blocks[j][i].MainSprite.Animating = false
 			g.Blocks[j][i].MainSprite.CFrame = rand.Intn(10)
 		}
@@ -134,25 +147,76 @@ func NewGameBoard(winWidth, winHeight, winDepth int, gamestate *gamestatetransit
 		}
 	}
 
+	// Hold block area
+	for j := 7; j < 9; j++ {
+		for i := 1; i < playAreaStart-1; i++ {
+			g.Blocks[j][i].MainSprite.SetAlpha(sdl.Color{R: 255, G: 255, B: 255, A: 64})
+		}
+	}
+
 	// Next block area
-	for j := 1; j < 3; j++ {
+	for j := 1; j < 7; j++ {
 		for i := playAreaEnd + 1; i < numAcross-1; i++ {
 			g.Blocks[j][i].MainSprite.SetAlpha(sdl.Color{R: 255, G: 255, B: 255, A: 64})
 		}
 	}
 
 	// De-gray area
-	for j := 4; j < 6; j++ {
+	for j := 7; j < 9; j++ {
 		for i := playAreaEnd + 1; i < numAcross-1; i++ {
 			g.Blocks[j][i].MainSprite.SetAlpha(sdl.Color{R: 255, G: 255, B: 255, A: 64})
 		}
 	}
 
-	// Set 'next' sprite
-	g.Blocks[2][(numAcross+playAreaEnd)/2].MainSprite.CSequence = rand.Intn(7)
-	g.SetBlockColoring((numAcross+playAreaEnd)/2, 2)
-	g.Blocks[2][(numAcross+playAreaEnd)/2].MainSprite.Animating = true
+	// Set the ghost blocks that show where the active blocks will land - one for each half of the pair of every player
+	g.GhostSprites = make([]*sprite.Sprite, 2*boardmodel.MaxPlayers)
+	for k := range g.GhostSprites {
+		g.GhostSprites[k] = sprite.NewSprite(
+			"assets/Gems.png",
+			vec3.Vector3{X: 0, Y: 0, Z: float32(winDepth)},
+			vec3.Vector3{X: 0, Y: 0, Z: 0},
+			64,
+			64,
+			float64(areaWidth/numAcross)/64,
+			float64(winHeight/numDown)/64,
+			10,
+			11,
+			0,
+			0,
+			false,
+			100,
+			true,
+			renderer)
+		g.GhostSprites[k].SetColorAndAlpha(sdl.Color{R: 255, G: 255, B: 255, A: 64})
+	}
+	g.ShowGhost = true
+
+	// Set the hint blocks that show where the active block makes the biggest match - one for each half of a pair
+	g.HintSprites = make([]*sprite.Sprite, 2)
+	for k := range g.HintSprites {
+		g.HintSprites[k] = sprite.NewSprite(
+			"assets/Gems.png",
+			vec3.Vector3{X: 0, Y: 0, Z: float32(winDepth)},
+			vec3.Vector3{X: 0, Y: 0, Z: 0},
+			64,
+			64,
+			float64(areaWidth/numAcross)/64,
+			float64(winHeight/numDown)/64,
+			10,
+			11,
+			0,
+			0,
+			false,
+			100,
+			true,
+			renderer)
+		g.HintSprites[k].SetColorAndAlpha(sdl.Color{R: 255, G: 255, B: 128, A: 160})
+	}
+	g.MaxHints = 3
+	g.HintsLeft = g.MaxHints
 
+	// The background fills the whole window - gameboards that share the window leave drawing it to the screen they are on
+	g.ShowBackground = true
 	g.Background = sprite.NewSprite(
 		"assets/background.png",
 		vec3.Vector3{X: 0, Y: 0, Z: 0},
@@ -170,45 +234,29 @@ func NewGameBoard(winWidth, winHeight, winDepth int, gamestate *gamestatetransit
 		false,
 		renderer)
 
-	g.LevelValue = 1
-	g.MaxLevelValue = 10
-	g.PrevLevelValue = g.LevelValue
-
-	g.ScoreValue = 0
-	g.MaxScoreValue = 9999999
-	g.PrevScoreValue = g.ScoreValue
-
-	g.DeGrayValue = 10
-	g.MaxDeGrayValue = 10
-	g.PrevDeGrayValue = g.DeGrayValue
-
-	g.BlockPointValue = 10
-
-	g.LevelScoreValue = 0
-	g.MaxLevelScoreValue = 100
+	g.PrevLevelValue = g.Board.LevelValue
+	g.PrevScoreValue = g.Board.ScoreValue
+	g.PrevDeGrayValue = g.Board.DeGrayValue
 
 	g.NumAcross = numAcross
 	g.NumDown = numDown
 	g.PlayAreaStart = playAreaStart
 	g.PlayAreaEnd = playAreaEnd
 
+	g.PreviewLength = 3
+	g.Players = 1
+	g.Rules = boardmodel.DefaultRules()
+	g.Levels = boardmodel.DefaultLevels()
+	g.Mode = boardmodel.EndlessMode()
+	g.Setup = StandardSetup()
+
 	g.ColorR = rand.Intn(256)
 	g.ColorG = rand.Intn(256)
 	g.ColorB = rand.Intn(256)
 	g.ColorTimer = 0.0
 
-	g.BlockStates = make([][]BlockState, numDown)
-	for j := range g.BlockStates {
-		g.BlockStates[j] = make([]BlockState, playAreaEnd-playAreaStart)
-		for i := range g.BlockStates[j] {
-			g.BlockStates[j][i] = Empty
-		}
-	}
-
-	g.CurrentActive = Pos{-1, -1}
-
-	// Set the font for the text
-	g.TextFont = font.NewTTFFont("assets/FifteenTwenty-Bold.otf", winWidth, winHeight)
+	// Set the font for the text - it is sized to the strip the gameboard is laid out in, and shrinks with the rows of a play area taller than the standard one
+	g.TextFont = font.NewTTFFont("assets/FifteenTwenty-Bold.otf", areaWidth*StandardHeight/numDown, winHeight)
 
 	// Set where the text goes on the screen
 	g.ScoreText = font.NewTTFString("Score:",
@@ -218,7 +266,7 @@ func NewGameBoard(winWidth, winHeight, winDepth int, gamestate *gamestatetransit
 		g.TextFont,
 		renderer)
 
-	g.ScoreValueText = font.NewTTFString(strconv.Itoa(g.ScoreValue),
+	g.ScoreValueText = font.NewTTFString(strconv.Itoa(g.Board.ScoreValue),
 		font.FontLarge,
 		sdl.Color{R: 255, G: 255, B: 255, A: 255},
 		vec3.Vector3{X: g.Blocks[2][1].MainSprite.Pos.X, Y: g.Blocks[2][1].MainSprite.Pos.Y, Z: 0},
@@ -232,13 +280,20 @@ func NewGameBoard(winWidth, winHeight, winDepth int, gamestate *gamestatetransit
 		g.TextFont,
 		renderer)
 
-	g.LevelValueText = font.NewTTFString(strconv.Itoa(g.LevelValue),
+	g.LevelValueText = font.NewTTFString(strconv.Itoa(g.Board.LevelValue),
 		font.FontLarge,
 		sdl.Color{R: 255, G: 255, B: 255, A: 255},
 		vec3.Vector3{X: g.Blocks[5][3].MainSprite.Pos.X, Y: g.Blocks[5][3].MainSprite.Pos.Y, Z: 0},
 		g.TextFont,
 		renderer)
 
+	g.HoldText = font.NewTTFString("Hold:",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: g.Blocks[7][1].MainSprite.Pos.X, Y: g.Blocks[7][1].MainSprite.Pos.Y, Z: 0},
+		g.TextFont,
+		renderer)
+
 	g.NextText = font.NewTTFString("Next:",
 		font.FontLarge,
 		sdl.Color{R: 255, G: 255, B: 255, A: 255},
@@ -249,37 +304,78 @@ func NewGameBoard(winWidth, winHeight, winDepth int, gamestate *gamestatetransit
 	g.DeGrayText = font.NewTTFString("De-Gray:",
 		font.FontLarge,
 		sdl.Color{R: 255, G: 255, B: 255, A: 255},
-		vec3.Vector3{X: g.Blocks[4][playAreaEnd+1].MainSprite.Pos.X, Y: g.Blocks[4][playAreaEnd+1].MainSprite.Pos.Y, Z: 0},
+		vec3.Vector3{X: g.Blocks[7][playAreaEnd+1].MainSprite.Pos.X, Y: g.Blocks[7][playAreaEnd+1].MainSprite.Pos.Y, Z: 0},
 		g.TextFont,
 		renderer)
 
-	g.DeGrayValueText = font.NewTTFString(strconv.Itoa(g.DeGrayValue),
+	g.DeGrayValueText = font.NewTTFString(strconv.Itoa(g.Board.DeGrayValue),
 		font.FontLarge,
 		sdl.Color{R: 255, G: 255, B: 255, A: 255},
-		vec3.Vector3{X: g.Blocks[5][playAreaEnd+3].MainSprite.Pos.X, Y: g.Blocks[5][playAreaEnd+3].MainSprite.Pos.Y, Z: 0},
+		vec3.Vector3{X: g.Blocks[8][playAreaEnd+3].MainSprite.Pos.X, Y: g.Blocks[8][playAreaEnd+3].MainSprite.Pos.Y, Z: 0},
 		g.TextFont,
 		renderer)
 
-	g.LevelFallingTime = float64(g.MaxLevelValue * 100)
-	g.LevelFallingTimer = 0
+	g.GoalText = font.NewTTFString(" ",
+		font.FontSmall,
+		sdl.Color{R: 255, G: 255, B: 0, A: 255},
+		vec3.Vector3{X: g.Blocks[9][1].MainSprite.Pos.X, Y: g.Blocks[9][1].MainSprite.Pos.Y, Z: 0},
+		g.TextFont,
+		renderer)
 
-	g.LevelPostFallTime = float64(g.MaxLevelValue*(g.MaxLevelValue-g.LevelValue)) + 1
-	g.LevelPostFallTimer = 0
+	// The clock shares its place with the goal, since puzzles have no time limit
+	g.PrevClock = g.Clock()
+	g.ClockText = font.NewTTFString(g.PrevClock,
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: g.Blocks[9][1].MainSprite.Pos.X, Y: g.Blocks[9][1].MainSprite.Pos.Y, Z: 0},
+		g.TextFont,
+		renderer)
 
-	g.BlocksFalling = 0
-	g.BlockFallingTime = 75
-	g.BlockFallingTimer = 0
+	g.PrevBlocksLeft = g.Board.BlocksLeft()
+	g.BlocksLeftText = font.NewTTFString("Left: "+strconv.Itoa(g.PrevBlocksLeft),
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: g.Blocks[9][playAreaEnd+1].MainSprite.Pos.X, Y: g.Blocks[9][playAreaEnd+1].MainSprite.Pos.Y, Z: 0},
+		g.TextFont,
+		renderer)
 
-	g.BlocksFallingTime = g.BlockFallingTime * float64(g.NumDown)
-	g.BlocksFallingTimer = 0
+	g.ChainText = font.NewTTFString(" ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 0, A: 255},
+		vec3.Vector3{X: g.Blocks[3][playAreaStart].MainSprite.Pos.X, Y: g.Blocks[3][playAreaStart].MainSprite.Pos.Y, Z: 0},
+		g.TextFont,
+		renderer)
+	g.ChainFlash = " "
+	g.PrevChainFlash = " "
 
-	g.GameOverTime = 1000
-	g.GameOverTimer = 0
+	// Show the upcoming blocks and the hold cell
+	g.SyncBlocks()
 
-	g.GameOverPausing = false
-	g.BlockScorePausing = false
+	return g
+}
 
-	g.BlocksForScore = 0
+// Destroy frees the textures and the font of the gameboard - the players and the game state it was made with are shared, so they are left alone
+func (g *GameBoard) Destroy() {
+	for j := range g.Blocks {
+		for i := range g.Blocks[j] {
+			g.Blocks[j][i].MainSprite.Destroy()
+			for k := range g.Blocks[j][i].ExplosionSprites {
+				g.Blocks[j][i].ExplosionSprites[k].MainSprite.Destroy()
+			}
+		}
+	}
 
-	return g
+	for k := range g.GhostSprites {
+		g.GhostSprites[k].Destroy()
+	}
+	for k := range g.HintSprites {
+		g.HintSprites[k].Destroy()
+	}
+	g.Background.Destroy()
+
+	texts := []*font.TTFString{g.ScoreText, g.ScoreValueText, g.LevelText, g.LevelValueText, g.HoldText, g.NextText, g.DeGrayText, g.DeGrayValueText, g.GoalText, g.ClockText, g.BlocksLeftText, g.ChainText}
+	for _, text := range texts {
+		text.Destroy()
+	}
+	g.TextFont.Close()
 }
@@ -0,0 +1,124 @@
+package gameboard
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"math"
+)
+
+// The sizes of play area that can be picked before a game - the panels either side of the play area need at least 10 rows
+const (
+	MinBoardWidth  = 4
+	MaxBoardWidth  = 8
+	MinBoardHeight = 10
+	MaxBoardHeight = 16
+)
+
+// The size of the standard play area - puzzles are made for it
+const (
+	StandardWidth  = 5
+	StandardHeight = 10
+)
+
+// MinPanelWidth is the fewest blocks across the panels either side of the play area can be
+const MinPanelWidth = 7
+
+// The numbers of colors a game can be picked to deal out - every scoring color of the gem sprite sheet comes before Gray
+const (
+	MinColors = 3
+	MaxColors = int(boardmodel.Gray)
+)
+
+// The chances of a block being a special gem once special gems are switched on, on levels that do not give chances of their own - one in fifty is a Bomb, one in fifty a Line and one in a hundred a Nuke
+const (
+	SpecialBombChance = 0.02
+	SpecialLineChance = 0.02
+	SpecialNukeChance = 0.01
+)
+
+// Setup holds the choices made before a single player game - the size of the play area, the level the game starts on and the mix of colors dealt out
+// Colors of 0 deals the colors of the level table, otherwise every level deals 'Colors' colors - special gems are only dealt if SpecialGems is set
+type Setup struct {
+	Width, Height int
+	StartLevel    int
+	Colors        int
+	GrayGems      bool
+	MultiGems     bool
+	SpecialGems   bool
+}
+
+// StandardSetup returns the setup of the standard game
+func StandardSetup() Setup {
+	return Setup{Width: StandardWidth, Height: StandardHeight, StartLevel: 1, Colors: 0, GrayGems: true, MultiGems: true, SpecialGems: false}
+}
+
+// ApplyLevels returns a copy of 'levels' with the mix of colors of the setup - the levels themselves are left alone
+func (s Setup) ApplyLevels(levels []boardmodel.Level) []boardmodel.Level {
+	applied := make([]boardmodel.Level, len(levels))
+	copy(applied, levels)
+
+	for l := range applied {
+		if s.Colors > 0 {
+			applied[l].Colors = s.Colors
+		}
+		if s.GrayGems == false {
+			applied[l].GrayChance = 0
+		}
+		if s.MultiGems == false {
+			applied[l].MultiChance = 0
+		}
+		if s.SpecialGems == false {
+			applied[l].BombChance = 0
+			applied[l].LineChance = 0
+			applied[l].NukeChance = 0
+		} else if applied[l].BombChance+applied[l].LineChance+applied[l].NukeChance == 0 {
+			applied[l].BombChance = SpecialBombChance
+			applied[l].LineChance = SpecialLineChance
+			applied[l].NukeChance = SpecialNukeChance
+		}
+	}
+
+	return applied
+}
+
+// Layout returns the number of blocks across a gameboard 'areaWidth' wide in a window 'winHeight' high, and the columns its play area starts and ends on, for a play area 'width' by 'height' blocks
+// The panels either side widen to keep the blocks about square as the play area gets taller
+func Layout(areaWidth, winHeight, width, height int) (numAcross, playAreaStart, playAreaEnd int) {
+	numAcross = width + 2*MinPanelWidth
+	square := int(math.Round(float64(areaWidth*height) / float64(winHeight)))
+	if square > numAcross {
+		numAcross = square
+	}
+
+	playAreaStart = (numAcross - width) / 2
+	playAreaEnd = playAreaStart + width
+
+	return numAcross, playAreaStart, playAreaEnd
+}
+
+// Resize lays the gameboard out again for a play area 'width' by 'height' blocks - the settings of the gameboard are kept, and a new game should be started on it
+func (g *GameBoard) Resize(width, height int) {
+	if width == g.Board.Width && height == g.Board.Height {
+		return
+	}
+
+	numAcross, playAreaStart, playAreaEnd := Layout(g.AreaWidth, g.WinHeight, width, height)
+	resized := NewGameBoard(g.WinWidth, g.WinHeight, g.WinDepth, g.AreaX, g.AreaWidth, g.CurrentGameState, numAcross, height, playAreaStart, playAreaEnd, g.Board.Rand.Int63(), g.MusicPlayer, g.SoundPlayer, g.Renderer)
+
+	resized.LastResults = g.LastResults
+	resized.PreviewLength = g.PreviewLength
+	resized.PairMode = g.PairMode
+	resized.Players = g.Players
+	resized.Rules = g.Rules
+	resized.Levels = g.Levels
+	resized.Mode = g.Mode
+	resized.Puzzles = g.Puzzles
+	resized.PuzzleIndex = g.PuzzleIndex
+	resized.ShowGhost = g.ShowGhost
+	resized.MaxHints = g.MaxHints
+	resized.ShowBackground = g.ShowBackground
+	resized.Setup = g.Setup
+
+	// Screens keep a pointer to the gameboard, so the new layout is copied into it rather than swapped for it - the old layout is freed first
+	g.Destroy()
+	*g = *resized
+}
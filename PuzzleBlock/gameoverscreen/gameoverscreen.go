@@ -0,0 +1,365 @@
+package gameoverscreen
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"golang-games/PuzzleBlock/font"
+	"golang-games/PuzzleBlock/gameboard"
+	"golang-games/PuzzleBlock/gamestate"
+	"golang-games/PuzzleBlock/gamestatetransition"
+	"golang-games/PuzzleBlock/guicontrols"
+	"golang-games/PuzzleBlock/highscores"
+	"golang-games/PuzzleBlock/musicplayer"
+	"golang-games/PuzzleBlock/soundplayer"
+	"golang-games/PuzzleBlock/sprite"
+	"golang-games/PuzzleBlock/vec3"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// GameOverScreen is a struct that contains all the sprite information for the game over screen
+type GameOverScreen struct {
+	CurrentGameState  *gamestatetransition.GameStateTransition
+	MouseState        *guicontrols.MouseState
+	MusicPlayer       *musicplayer.MusicPlayer
+	SoundPlayer       *soundplayer.SoundPlayer
+	GameBoard         *gameboard.GameBoard
+	Scores            *highscores.Table
+	WinWidth          int
+	WinHeight         int
+	Background        *sprite.Sprite
+	TextFont          *font.TTFFont
+	TitleText         *font.TTFString
+	ModeText          *font.TTFString
+	CurrentResults    boardmodel.Results
+	PrevResults       boardmodel.Results
+	EnteringName      bool
+	ScoreText         *font.TTFString
+	LevelText         *font.TTFString
+	BlocksClearedText *font.TTFString
+	PlayTimeText      *font.TTFString
+	NewHighScoreText  *font.TTFString
+	NameInput         *guicontrols.TextInput
+	RetryButton       *guicontrols.TextButton
+	MainMenuButton    *guicontrols.TextButton
+}
+
+// NewGameOverScreen is a game over screen constructor
+func NewGameOverScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetransition.GameStateTransition, mousestate *guicontrols.MouseState, gameboard *gameboard.GameBoard, scores *highscores.Table, musicplayer *musicplayer.MusicPlayer, soundplayer *soundplayer.SoundPlayer, renderer *sdl.Renderer) *GameOverScreen {
+
+	o := &GameOverScreen{}
+
+	o.CurrentGameState = gamestate
+
+	o.MouseState = mousestate
+
+	o.MusicPlayer = musicplayer
+
+	o.SoundPlayer = soundplayer
+
+	o.GameBoard = gameboard
+
+	o.Scores = scores
+
+	o.WinWidth = winWidth
+	o.WinHeight = winHeight
+
+	// Set the background image
+	o.Background = sprite.NewSprite(
+		"assets/background.png",
+		vec3.Vector3{X: 0, Y: 0, Z: 0},
+		vec3.Vector3{X: 0, Y: 0, Z: 0},
+		1280,
+		720,
+		float64(winWidth)/1280,
+		float64(winHeight)/720,
+		1,
+		1,
+		0,
+		0,
+		true,
+		0,
+		false,
+		renderer)
+
+	// Set the font for the text
+	o.TextFont = font.NewTTFFont("assets/FifteenTwenty-Bold.otf", winWidth, winHeight)
+
+	// Set the title text
+	o.TitleText = font.NewTTFString(title(o.GameBoard.LastResults),
+		font.FontTitle,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: 0, Y: float32(winHeight) * 0.05, Z: 0},
+		o.TextFont,
+		renderer)
+	o.TitleText.SetCenterX()
+
+	o.CurrentResults = o.GameBoard.LastResults
+	o.PrevResults = o.GameBoard.LastResults
+
+	// Set the mode text
+	o.ModeText = font.NewTTFString(o.PrevResults.Mode.Name+" ",
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 0, A: 255},
+		vec3.Vector3{X: 0, Y: float32(o.WinHeight) * 0.30, Z: 0},
+		o.TextFont,
+		renderer)
+	o.ModeText.SetCenterX()
+
+	// Set the results text
+	o.ScoreText = font.NewTTFString("Score: "+strconv.Itoa(o.PrevResults.Score),
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: 0, Y: float32(o.WinHeight) * 0.36, Z: 0},
+		o.TextFont,
+		renderer)
+	o.ScoreText.SetCenterX()
+
+	o.LevelText = font.NewTTFString("Level: "+strconv.Itoa(o.PrevResults.Level),
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: 0, Y: float32(o.WinHeight) * 0.42, Z: 0},
+		o.TextFont,
+		renderer)
+	o.LevelText.SetCenterX()
+
+	o.BlocksClearedText = font.NewTTFString("Blocks Cleared: "+strconv.Itoa(o.PrevResults.BlocksCleared),
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: 0, Y: float32(o.WinHeight) * 0.48, Z: 0},
+		o.TextFont,
+		renderer)
+	o.BlocksClearedText.SetCenterX()
+
+	o.PlayTimeText = font.NewTTFString(playTime(o.PrevResults),
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: 0, Y: float32(o.WinHeight) * 0.54, Z: 0},
+		o.TextFont,
+		renderer)
+	o.PlayTimeText.SetCenterX()
+
+	// Set the high score prompt and the box the player's name is typed into
+	o.NewHighScoreText = font.NewTTFString("New High Score! Enter your name:",
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 0, A: 255},
+		vec3.Vector3{X: 0, Y: float32(o.WinHeight) * 0.60, Z: 0},
+		o.TextFont,
+		renderer)
+	o.NewHighScoreText.SetCenterX()
+
+	o.NameInput = guicontrols.NewTextInput(o.WinWidth,
+		o.WinHeight,
+		12,
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		vec3.Vector3{X: 0, Y: float32(o.WinHeight) * 0.66, Z: 0},
+		int(float32(o.WinWidth)*0.4),
+		500,
+		o.TextFont,
+		renderer)
+	o.NameInput.SetCenterX()
+
+	o.RetryButton = guicontrols.NewTextButton(o.WinWidth,
+		o.WinHeight,
+		"   Retry   ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(o.WinHeight) * 0.74, Z: 0},
+		0.1,
+		100,
+		o.TextFont,
+		renderer)
+	o.RetryButton.SetCenterX()
+
+	o.MainMenuButton = guicontrols.NewTextButton(o.WinWidth,
+		o.WinHeight,
+		" Main Menu ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(o.WinHeight) * 0.86, Z: 0},
+		0.1,
+		100,
+		o.TextFont,
+		renderer)
+	o.MainMenuButton.SetCenterX()
+
+	return o
+}
+
+// Update updates all the objects on the game over screen
+func (o *GameOverScreen) Update(time float64) {
+
+	// Check whether a game that has just ended made it into the high score table of its mode - a mode won on time only counts if it was finished
+	if o.GameBoard.LastResults != o.CurrentResults {
+		o.CurrentResults = o.GameBoard.LastResults
+		o.EnteringName = o.Scores.Qualifies(o.resultsEntry("Player"))
+		if o.CurrentResults.Mode.RankedByTime() == true && o.CurrentResults.Completed == false {
+			o.EnteringName = false
+		}
+		o.NameInput.Clear()
+	}
+
+	// Start a new game if the retry button is clicked
+	if o.RetryButton.WasLeftClicked == true && o.CurrentGameState.TransitioningUp == false {
+		o.SaveHighScore()
+		o.GameBoard.NewGame()
+		o.MusicPlayer.FutureTune = o.MusicPlayer.PastTune
+		o.CurrentGameState.TransitioningUp = true
+		o.CurrentGameState.ToState = gamestate.MainGame
+	}
+
+	// Return to the title screen if the main menu button is clicked
+	if o.MainMenuButton.WasLeftClicked == true && o.CurrentGameState.TransitioningUp == false {
+		o.SaveHighScore()
+		o.GameBoard.NewGame()
+		o.MusicPlayer.FutureTune = 0
+		o.CurrentGameState.TransitioningUp = true
+		o.CurrentGameState.ToState = gamestate.TitleScreen
+	}
+
+	// Update the name box
+	if o.EnteringName == true {
+		o.NameInput.Update(time)
+	}
+
+	// Update the buttons
+	o.RetryButton.Update(o.MouseState, time)
+	o.MainMenuButton.Update(o.MouseState, time)
+}
+
+// TextInput passes text typed on the keyboard to the name box
+func (o *GameOverScreen) TextInput(text string) {
+	if o.EnteringName == true {
+		o.NameInput.AppendText(text)
+	}
+}
+
+// KeyDown handles the keys that edit and confirm the name box
+func (o *GameOverScreen) KeyDown(key sdl.Scancode) {
+	if o.EnteringName == true {
+		switch key {
+		case sdl.SCANCODE_BACKSPACE:
+			o.NameInput.Backspace()
+		case sdl.SCANCODE_RETURN:
+			o.SaveHighScore()
+		}
+	}
+}
+
+// SaveHighScore adds the last game to the high score table under the name in the name box - an empty name is saved as 'Player'
+func (o *GameOverScreen) SaveHighScore() {
+	if o.EnteringName == false {
+		return
+	}
+
+	name := strings.TrimSpace(o.NameInput.Value)
+	if name == "" {
+		name = "Player"
+	}
+
+	o.Scores.Insert(o.resultsEntry(name))
+
+	err := o.Scores.Save()
+	if err != nil {
+		log.Println(err)
+	}
+
+	o.EnteringName = false
+}
+
+// resultsEntry turns the results of the last game into a high score entry under 'name'
+func (o *GameOverScreen) resultsEntry(name string) highscores.Entry {
+	e := highscores.Entry{
+		Name:  name,
+		Score: o.CurrentResults.Score,
+		Level: o.CurrentResults.Level,
+		Date:  time.Now(),
+		Mode:  o.CurrentResults.Mode.Name}
+
+	if o.CurrentResults.Mode.RankedByTime() == true {
+		e.Time = o.CurrentResults.PlayTime
+	}
+
+	return e
+}
+
+// title returns the heading for a finished game - timed modes that ran their course did not end by filling up
+func title(results boardmodel.Results) string {
+	if results.Completed == true && results.Mode.RankedByTime() == true {
+		return "Finished!"
+	} else if results.Completed == true {
+		return "Time Up!"
+	}
+	return "Game Over"
+}
+
+// playTime returns the play time line of the results - to the hundredth of a second in modes that are won on time
+func playTime(results boardmodel.Results) string {
+	if results.Mode.RankedByTime() == true {
+		return "Time: " + boardmodel.FormatTimePrecise(results.PlayTime)
+	}
+	return "Play Time: " + boardmodel.FormatTime(results.PlayTime)
+}
+
+// highScorePrompt returns the line asking for a name when a game makes it into the table
+func highScorePrompt(results boardmodel.Results) string {
+	if results.Mode.RankedByTime() == true {
+		return "New Best Time! Enter your name:"
+	}
+	return "New High Score! Enter your name:"
+}
+
+// Draw draws all the objects on the game over screen
+func (o *GameOverScreen) Draw(renderer *sdl.Renderer) {
+
+	// Draw the background
+	o.Background.Draw(renderer)
+
+	// Change the display text when a new game has ended
+	if o.CurrentResults != o.PrevResults {
+		o.TitleText.ChangeStringTexture(title(o.CurrentResults), font.FontTitle, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		o.TitleText.SetCenterX()
+		o.ModeText.ChangeStringTexture(o.CurrentResults.Mode.Name+" ", font.FontMedium, sdl.Color{R: 255, G: 255, B: 0, A: 255}, renderer)
+		o.ModeText.SetCenterX()
+		o.ScoreText.ChangeStringTexture("Score: "+strconv.Itoa(o.CurrentResults.Score), font.FontMedium, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		o.ScoreText.SetCenterX()
+		o.LevelText.ChangeStringTexture("Level: "+strconv.Itoa(o.CurrentResults.Level), font.FontMedium, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		o.LevelText.SetCenterX()
+		o.BlocksClearedText.ChangeStringTexture("Blocks Cleared: "+strconv.Itoa(o.CurrentResults.BlocksCleared), font.FontMedium, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		o.BlocksClearedText.SetCenterX()
+		o.PlayTimeText.ChangeStringTexture(playTime(o.CurrentResults), font.FontMedium, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		o.PlayTimeText.SetCenterX()
+		o.NewHighScoreText.ChangeStringTexture(highScorePrompt(o.CurrentResults), font.FontMedium, sdl.Color{R: 255, G: 255, B: 0, A: 255}, renderer)
+		o.NewHighScoreText.SetCenterX()
+		o.PrevResults = o.CurrentResults
+	}
+
+	// Draw the text
+	o.TitleText.Draw(renderer)
+	o.ModeText.Draw(renderer)
+	o.ScoreText.Draw(renderer)
+	o.LevelText.Draw(renderer)
+	o.BlocksClearedText.Draw(renderer)
+	o.PlayTimeText.Draw(renderer)
+
+	// Draw the name box while a high score is waiting for a name
+	if o.EnteringName == true {
+		o.NewHighScoreText.Draw(renderer)
+		o.NameInput.Draw(renderer)
+	}
+
+	// Draw the buttons
+	o.RetryButton.Draw(renderer)
+	o.MainMenuButton.Draw(renderer)
+}
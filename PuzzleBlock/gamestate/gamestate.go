@@ -8,10 +8,30 @@ const (
 	StartUp GameState = iota
 	// TitleScreen is the first screen the player sees
 	TitleScreen
+	// Demo is a game the computer plays by itself while the title screen is left alone
+	Demo
 	// OptionsScreen allows the player to set various options like sound volume, number of levels, etc.
 	OptionsScreen
+	// ModeSelect is where the mode of the next game is picked
+	ModeSelect
+	// GameSetup is where the size of the board, the starting level and the mix of colors of a single player game are picked
+	GameSetup
+	// OnlineSetup is where an online versus match is hosted or joined
+	OnlineSetup
 	// MainGame is where the game is actually played
 	MainGame
+	// Versus is where two players play against each other side by side
+	Versus
+	// Coop is where two players share one wide board and its score
+	Coop
+	// Paused freezes the game and shows the pause menu over it
+	Paused
+	// GameOver shows the results of the game that just ended
+	GameOver
+	// HighScores shows the best scores saved on this computer
+	HighScores
+	// PuzzleResult shows whether the puzzle that just ended was solved
+	PuzzleResult
 	// QuitGame exits the game
 	QuitGame
 )
@@ -389,3 +389,117 @@ func (button *TextButton) Draw(renderer *sdl.Renderer) {
 	}
 	button.Text.Draw(renderer)
 }
+
+// TextInput structs contain all the information needed for a box the player can type a single line of text into
+type TextInput struct {
+	WinWidth    int
+	WinHeight   int
+	Text        *font.TTFString
+	Background  *texturedrawing.SinglePixelTexture
+	Size        font.TextSize
+	TextColor   sdl.Color
+	Value       string
+	PrevValue   string
+	MaxLength   int
+	ShowCursor  bool
+	PrevCursor  bool
+	CursorSpeed int
+	CursorTimer float64
+}
+
+// NewTextInput is a 'constructor' for a TextInput struct - the box is 'w' pixels wide and tall enough for one line of text
+func NewTextInput(winWidth, winHeight int, maxLength int, size font.TextSize, textColor, backgroundColor sdl.Color, pos vec3.Vector3, w int, cursorSpeedMS int, textFont *font.TTFFont, renderer *sdl.Renderer) *TextInput {
+
+	input := &TextInput{}
+
+	input.WinWidth = winWidth
+	input.WinHeight = winHeight
+
+	input.Size = size
+	input.TextColor = textColor
+
+	input.MaxLength = maxLength
+
+	input.ShowCursor = true
+	input.PrevCursor = true
+	input.CursorSpeed = cursorSpeedMS
+
+	// An empty string can not be rendered, so the cursor (or a space in its place) always follows the text
+	input.Text = font.NewTTFString(input.displayText(), size, textColor, pos, textFont, renderer)
+
+	_, _, _, h, err := input.Text.StringTexture.Query()
+	if err != nil {
+		panic(err)
+	}
+
+	input.Background = texturedrawing.NewSinglePixelTexture(backgroundColor, sdl.Rect{X: int32(pos.X), Y: int32(pos.Y), W: int32(w), H: h}, renderer)
+
+	return input
+}
+
+// SetCenterX sets the position of the box to the center of the screen
+func (input *TextInput) SetCenterX() {
+	input.Background.Rect.X = (int32(input.WinWidth) - input.Background.Rect.W) / 2
+}
+
+// AppendText adds typed text to the end of the value, up to MaxLength characters
+func (input *TextInput) AppendText(text string) {
+	for _, r := range text {
+		if len([]rune(input.Value)) >= input.MaxLength {
+			break
+		}
+		input.Value += string(r)
+	}
+}
+
+// Backspace removes the last character of the value
+func (input *TextInput) Backspace() {
+	runes := []rune(input.Value)
+	if len(runes) > 0 {
+		input.Value = string(runes[:len(runes)-1])
+	}
+}
+
+// Clear empties the value
+func (input *TextInput) Clear() {
+	input.Value = ""
+}
+
+// Update blinks the cursor
+func (input *TextInput) Update(time float64) {
+	input.CursorTimer += time
+	if input.CursorTimer >= float64(input.CursorSpeed) {
+		input.ShowCursor = !input.ShowCursor
+		input.CursorTimer = 0
+	}
+}
+
+// Draw draws the box and the text typed into it
+func (input *TextInput) Draw(renderer *sdl.Renderer) {
+
+	// Change the text texture when the value or the cursor changes
+	if input.Value != input.PrevValue || input.ShowCursor != input.PrevCursor {
+		input.Text.ChangeStringTexture(input.displayText(), input.Size, input.TextColor, renderer)
+		input.PrevValue = input.Value
+		input.PrevCursor = input.ShowCursor
+	}
+
+	// Keep the text centered in the box
+	_, _, w, _, err := input.Text.StringTexture.Query()
+	if err != nil {
+		panic(err)
+	}
+	input.Text.Pos.X = float32(input.Background.Rect.X + (input.Background.Rect.W-w)/2)
+	input.Text.Pos.Y = float32(input.Background.Rect.Y)
+
+	input.Background.Draw(renderer)
+	input.Text.Draw(renderer)
+}
+
+// displayText returns the value followed by the cursor when it is showing
+func (input *TextInput) displayText() string {
+	if input.ShowCursor == true {
+		return input.Value + "_"
+	}
+	return input.Value + " "
+}
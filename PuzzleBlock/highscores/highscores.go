@@ -0,0 +1,155 @@
+package highscores
+
+import (
+	"encoding/json"
+	"golang-games/PuzzleBlock/userdata"
+	"io/ioutil"
+	"os"
+	"sort"
+	"time"
+)
+
+// MaxEntries is the number of scores kept for each mode
+const MaxEntries = 10
+
+// DefaultMode is the mode of the standard endless game
+const DefaultMode = "Endless"
+
+// FileName is the name of the high score file in the user's config directory
+const FileName = "highscores.json"
+
+// Entry is a single score in the high score table - entries of modes that are won on time have a Time in milliseconds and are ranked by it instead of by Score
+type Entry struct {
+	Name  string    `json:"name"`
+	Score int       `json:"score"`
+	Level int       `json:"level"`
+	Time  float64   `json:"time,omitempty"`
+	Date  time.Time `json:"date"`
+	Mode  string    `json:"mode"`
+}
+
+// Better returns true if 'a' ranks above 'b' - a faster time or a higher score, with earlier dates winning ties
+func Better(a, b Entry) bool {
+	if a.Time > 0 || b.Time > 0 {
+		if a.Time != b.Time {
+			return b.Time <= 0 || (a.Time > 0 && a.Time < b.Time)
+		}
+	} else if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	return a.Date.Before(b.Date)
+}
+
+// Table holds the best scores of every mode and where they are saved
+type Table struct {
+	Entries  []Entry `json:"entries"`
+	Path     string  `json:"-"`
+	Revision int     `json:"-"`
+}
+
+// NewTable creates an empty high score table that is saved to 'path'
+func NewTable(path string) *Table {
+	return &Table{Entries: make([]Entry, 0), Path: path}
+}
+
+// Load reads a high score table from 'path' - a missing file gives an empty table
+func Load(path string) (*Table, error) {
+	t := NewTable(path)
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return t, nil
+	}
+	if err != nil {
+		return t, err
+	}
+
+	err = json.Unmarshal(data, t)
+	if err != nil {
+		return NewTable(path), err
+	}
+
+	t.sortEntries()
+
+	return t, nil
+}
+
+// LoadFromUserData reads the high score table kept in the user's config directory
+func LoadFromUserData() (*Table, error) {
+	path, err := userdata.Path(FileName)
+	if err != nil {
+		return NewTable(""), err
+	}
+
+	return Load(path)
+}
+
+// Save writes the table back to the file it was loaded from
+func (t *Table) Save() error {
+	data, err := json.MarshalIndent(t, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(t.Path, data, 0644)
+}
+
+// ForMode returns the entries of 'mode', best first
+func (t *Table) ForMode(mode string) []Entry {
+	entries := make([]Entry, 0)
+	for _, e := range t.Entries {
+		if e.Mode == mode {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// Qualifies returns true if 'e' is good enough to make it into the table for its mode
+func (t *Table) Qualifies(e Entry) bool {
+	if e.Score <= 0 && e.Time <= 0 {
+		return false
+	}
+
+	entries := t.ForMode(e.Mode)
+	return len(entries) < MaxEntries || Better(e, entries[len(entries)-1])
+}
+
+// Insert adds an entry to the table, dropping the worst entry of its mode if the mode is full, and returns its rank starting from 1 - or 0 if it did not qualify
+func (t *Table) Insert(e Entry) int {
+	if t.Qualifies(e) == false {
+		return 0
+	}
+
+	t.Entries = append(t.Entries, e)
+	t.sortEntries()
+
+	// Drop everything below the last place of the entry's mode
+	rank := 0
+	count := 0
+	kept := make([]Entry, 0, len(t.Entries))
+	for _, entry := range t.Entries {
+		if entry.Mode == e.Mode {
+			count++
+			if count > MaxEntries {
+				continue
+			}
+			if entry == e {
+				rank = count
+			}
+		}
+		kept = append(kept, entry)
+	}
+	t.Entries = kept
+
+	t.Revision++
+
+	return rank
+}
+
+// sortEntries puts the entries in order from best to worst
+func (t *Table) sortEntries() {
+	sort.SliceStable(t.Entries, func(i, j int) bool {
+		return Better(t.Entries[i], t.Entries[j])
+	})
+}
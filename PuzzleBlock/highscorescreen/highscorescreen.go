@@ -0,0 +1,280 @@
+package highscorescreen
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"golang-games/PuzzleBlock/font"
+	"golang-games/PuzzleBlock/gamestate"
+	"golang-games/PuzzleBlock/gamestatetransition"
+	"golang-games/PuzzleBlock/guicontrols"
+	"golang-games/PuzzleBlock/highscores"
+	"golang-games/PuzzleBlock/musicplayer"
+	"golang-games/PuzzleBlock/soundplayer"
+	"golang-games/PuzzleBlock/sprite"
+	"golang-games/PuzzleBlock/vec3"
+	"strconv"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Column positions of the table as fractions of the window width
+var columnX = []float32{0.1, 0.17, 0.5, 0.66, 0.76}
+
+// HighScoreRow holds the text of one line of the high score table
+type HighScoreRow struct {
+	RankText  *font.TTFString
+	NameText  *font.TTFString
+	ScoreText *font.TTFString
+	LevelText *font.TTFString
+	DateText  *font.TTFString
+}
+
+// HighScoreScreen is a struct that contains all the sprite information for the high score screen
+type HighScoreScreen struct {
+	CurrentGameState *gamestatetransition.GameStateTransition
+	MouseState       *guicontrols.MouseState
+	MusicPlayer      *musicplayer.MusicPlayer
+	SoundPlayer      *soundplayer.SoundPlayer
+	Scores           *highscores.Table
+	Modes            []boardmodel.Mode
+	ModeIndex        int
+	PrevModeIndex    int
+	PrevRevision     int
+	WinWidth         int
+	WinHeight        int
+	Background       *sprite.Sprite
+	TextFont         *font.TTFFont
+	TitleText        *font.TTFString
+	HeaderRow        *HighScoreRow
+	Rows             []*HighScoreRow
+	ModeText         *font.TTFString
+	ModeUpButton     *guicontrols.SpriteButton
+	ModeDownButton   *guicontrols.SpriteButton
+	BackButton       *guicontrols.TextButton
+}
+
+// NewHighScoreScreen is a high score screen constructor
+func NewHighScoreScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetransition.GameStateTransition, mousestate *guicontrols.MouseState, scores *highscores.Table, musicplayer *musicplayer.MusicPlayer, soundplayer *soundplayer.SoundPlayer, renderer *sdl.Renderer) *HighScoreScreen {
+
+	h := &HighScoreScreen{}
+
+	h.CurrentGameState = gamestate
+
+	h.MouseState = mousestate
+
+	h.MusicPlayer = musicplayer
+
+	h.SoundPlayer = soundplayer
+
+	h.Scores = scores
+	h.Modes = boardmodel.Modes()
+	h.ModeIndex = 0
+
+	// Make sure the table is filled in the first time it is drawn
+	h.PrevModeIndex = -1
+	h.PrevRevision = -1
+
+	h.WinWidth = winWidth
+	h.WinHeight = winHeight
+
+	// Set the background image
+	h.Background = sprite.NewSprite(
+		"assets/background.png",
+		vec3.Vector3{X: 0, Y: 0, Z: 0},
+		vec3.Vector3{X: 0, Y: 0, Z: 0},
+		1280,
+		720,
+		float64(winWidth)/1280,
+		float64(winHeight)/720,
+		1,
+		1,
+		0,
+		0,
+		true,
+		0,
+		false,
+		renderer)
+
+	// Set the font for the text
+	h.TextFont = font.NewTTFFont("assets/FifteenTwenty-Bold.otf", winWidth, winHeight)
+
+	// Set the title text
+	h.TitleText = font.NewTTFString("High Scores",
+		font.FontTitle,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: 0, Y: float32(winHeight) * 0.05, Z: 0},
+		h.TextFont,
+		renderer)
+	h.TitleText.SetCenterX()
+
+	// Set the column headings
+	h.HeaderRow = h.newRow([]string{"#", "Name", "Score", "Level", "Date"}, font.FontSmall, float32(winHeight)*0.22, renderer)
+
+	// Set the rows of the table - they are filled in when the screen is drawn
+	h.Rows = make([]*HighScoreRow, highscores.MaxEntries)
+	for i := range h.Rows {
+		h.Rows[i] = h.newRow([]string{strconv.Itoa(i+1) + ".", "---", " ", " ", " "}, font.FontMedium, float32(winHeight)*(0.27+0.056*float32(i)), renderer)
+	}
+
+	// Set the mode text and the arrows that flip through the table of each mode
+	h.ModeText = font.NewTTFString(h.Modes[h.ModeIndex].Name,
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 0, A: 255},
+		vec3.Vector3{X: float32(h.WinWidth) * 0.08, Y: float32(h.WinHeight) * 0.87, Z: 0},
+		h.TextFont,
+		renderer)
+
+	h.ModeUpButton = guicontrols.NewSpriteButton(h.WinWidth,
+		h.WinHeight,
+		"assets/arrowRight.png",
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: float32(h.WinWidth) * 0.31, Y: float32(h.WinHeight) * 0.85, Z: 0},
+		0.1,
+		100,
+		64,
+		64,
+		1,
+		1,
+		renderer)
+
+	h.ModeDownButton = guicontrols.NewSpriteButton(h.WinWidth,
+		h.WinHeight,
+		"assets/arrowLeft.png",
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: float32(h.WinWidth) * 0.02, Y: float32(h.WinHeight) * 0.85, Z: 0},
+		0.1,
+		100,
+		64,
+		64,
+		1,
+		1,
+		renderer)
+
+	h.BackButton = guicontrols.NewTextButton(h.WinWidth,
+		h.WinHeight,
+		"   Back   ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(h.WinHeight) * 0.85, Z: 0},
+		0.1,
+		100,
+		h.TextFont,
+		renderer)
+	h.BackButton.SetCenterX()
+
+	return h
+}
+
+// newRow creates the text for one line of the table at height 'y'
+func (h *HighScoreScreen) newRow(columns []string, size font.TextSize, y float32, renderer *sdl.Renderer) *HighScoreRow {
+	texts := make([]*font.TTFString, len(columns))
+	for i := range columns {
+		texts[i] = font.NewTTFString(columns[i],
+			size,
+			sdl.Color{R: 255, G: 255, B: 255, A: 255},
+			vec3.Vector3{X: float32(h.WinWidth) * columnX[i], Y: y, Z: 0},
+			h.TextFont,
+			renderer)
+	}
+
+	return &HighScoreRow{texts[0], texts[1], texts[2], texts[3], texts[4]}
+}
+
+// Update updates all the objects on the high score screen
+func (h *HighScoreScreen) Update(time float64) {
+
+	// Return to the title screen if the back button is clicked
+	if h.BackButton.WasLeftClicked == true {
+		h.MusicPlayer.FutureTune = 0
+		h.CurrentGameState.TransitioningUp = true
+		h.CurrentGameState.ToState = gamestate.TitleScreen
+	}
+
+	// Flip through the modes
+	if h.ModeUpButton.WasLeftClicked == true {
+		h.ModeIndex++
+		if h.ModeIndex > len(h.Modes)-1 {
+			h.ModeIndex = 0
+		}
+	}
+
+	if h.ModeDownButton.WasLeftClicked == true {
+		h.ModeIndex--
+		if h.ModeIndex < 0 {
+			h.ModeIndex = len(h.Modes) - 1
+		}
+	}
+
+	// Update the buttons
+	h.ModeUpButton.Update(h.MouseState, time)
+	h.ModeDownButton.Update(h.MouseState, time)
+	h.BackButton.Update(h.MouseState, time)
+}
+
+// Draw draws all the objects on the high score screen
+func (h *HighScoreScreen) Draw(renderer *sdl.Renderer) {
+
+	// Draw the background
+	h.Background.Draw(renderer)
+
+	// Change the table text when a score has been added or another mode has been picked - modes won on time list times in place of scores
+	if h.Scores.Revision != h.PrevRevision || h.ModeIndex != h.PrevModeIndex {
+		mode := h.Modes[h.ModeIndex]
+		h.ModeText.ChangeStringTexture(mode.Name, font.FontMedium, sdl.Color{R: 255, G: 255, B: 0, A: 255}, renderer)
+		if mode.RankedByTime() == true {
+			h.HeaderRow.ScoreText.ChangeStringTexture("Time", font.FontSmall, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		} else {
+			h.HeaderRow.ScoreText.ChangeStringTexture("Score", font.FontSmall, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		}
+
+		entries := h.Scores.ForMode(mode.Name)
+		for i := range h.Rows {
+			name, score, level, date := "---", " ", " ", " "
+			if i < len(entries) {
+				name = entries[i].Name
+				score = strconv.Itoa(entries[i].Score)
+				if mode.RankedByTime() == true {
+					score = boardmodel.FormatTimePrecise(entries[i].Time)
+				}
+				level = strconv.Itoa(entries[i].Level)
+				date = entries[i].Date.Format("2006-01-02")
+			}
+			h.Rows[i].NameText.ChangeStringTexture(name, font.FontMedium, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+			h.Rows[i].ScoreText.ChangeStringTexture(score, font.FontMedium, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+			h.Rows[i].LevelText.ChangeStringTexture(level, font.FontMedium, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+			h.Rows[i].DateText.ChangeStringTexture(date, font.FontMedium, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		}
+		h.PrevRevision = h.Scores.Revision
+		h.PrevModeIndex = h.ModeIndex
+	}
+
+	// Draw the text
+	h.TitleText.Draw(renderer)
+	h.HeaderRow.Draw(renderer)
+	for i := range h.Rows {
+		h.Rows[i].Draw(renderer)
+	}
+
+	// Draw the mode
+	h.ModeText.Draw(renderer)
+
+	// Draw the buttons
+	h.ModeUpButton.Draw(renderer)
+	h.ModeDownButton.Draw(renderer)
+	h.BackButton.Draw(renderer)
+}
+
+// Draw draws every column of the row
+func (r *HighScoreRow) Draw(renderer *sdl.Renderer) {
+	r.RankText.Draw(renderer)
+	r.NameText.Draw(renderer)
+	r.ScoreText.Draw(renderer)
+	r.LevelText.Draw(renderer)
+	r.DateText.Draw(renderer)
+}
@@ -1,7 +1,11 @@
 package main
 
 import (
+	"golang-games/PuzzleBlock/coopscreen"
 	"golang-games/PuzzleBlock/gameboard"
+	"golang-games/PuzzleBlock/gamestate"
+	"golang-games/PuzzleBlock/pausescreen"
+	"golang-games/PuzzleBlock/versusscreen"
 
 	"github.com/veandco/go-sdl2/sdl"
 )
@@ -25,11 +29,27 @@ func KeyPressed(key uint8) bool {
 	return keyboardState[key] == 0 && prevKeyboardState[key] == 1
 }
 
-func getKeyboardState(g *gameboard.GameBoard) {
-	if sdl.GetKeyboardFocus() == window || sdl.GetMouseFocus() == window {
+func getKeyboardState(g *gameboard.GameBoard, p *pausescreen.PauseScreen) {
 
-		if KeyDownOnce(sdl.SCANCODE_UP) {
-			g.MoveActiveBlock("up")
+	// Pause the game whenever the window loses keyboard focus
+	if sdl.GetKeyboardFocus() != window {
+		p.Pause()
+		return
+	}
+
+	// Escape and P pause and unpause the game
+	if KeyDownOnce(sdl.SCANCODE_ESCAPE) || KeyDownOnce(sdl.SCANCODE_P) {
+		if p.CurrentGameState.CurrentGameState == gamestate.Paused {
+			p.Resume()
+		} else {
+			p.Pause()
+		}
+	} else if p.CurrentGameState.CurrentGameState == gamestate.MainGame {
+		if KeyDownOnce(sdl.SCANCODE_UP) || KeyDownOnce(sdl.SCANCODE_X) {
+			g.MoveActiveBlock("rotate_cw")
+		}
+		if KeyDownOnce(sdl.SCANCODE_Z) {
+			g.MoveActiveBlock("rotate_ccw")
 		}
 		if KeyDownOnce(sdl.SCANCODE_DOWN) {
 			g.MoveActiveBlock("down")
@@ -40,10 +60,121 @@ func getKeyboardState(g *gameboard.GameBoard) {
 		if KeyDownOnce(sdl.SCANCODE_RIGHT) {
 			g.MoveActiveBlock("right")
 		}
+		if KeyDownOnce(sdl.SCANCODE_SPACE) {
+			g.MoveActiveBlock("drop")
+		}
+		if KeyDownOnce(sdl.SCANCODE_G) {
+			g.ShowGhost = !g.ShowGhost
+		}
+		if KeyDownOnce(sdl.SCANCODE_H) {
+			g.ShowHint()
+		}
+		if KeyDownOnce(sdl.SCANCODE_C) || KeyDownOnce(sdl.SCANCODE_LSHIFT) {
+			g.MoveActiveBlock("hold")
+		}
+	}
 
-		for i, v := range keyboardState {
-			prevKeyboardState[i] = v
+	for i, v := range keyboardState {
+		prevKeyboardState[i] = v
+	}
+}
+
+// getVersusKeyboardState moves the blocks of both players of a versus match - player 1 plays on the left with WASD, player 2 on the right with the arrow keys
+func getVersusKeyboardState(v *versusscreen.VersusScreen) {
+
+	// Pause the match whenever the window loses keyboard focus
+	if sdl.GetKeyboardFocus() != window {
+		v.Pause()
+		return
+	}
+
+	// Escape and P pause and unpause the match
+	if KeyDownOnce(sdl.SCANCODE_ESCAPE) || KeyDownOnce(sdl.SCANCODE_P) {
+		if v.Paused == true {
+			v.Resume()
+		} else {
+			v.Pause()
 		}
+	} else {
+		getPlayerKeys(v.MoveActiveBlock)
+	}
+
+	for i, state := range keyboardState {
+		prevKeyboardState[i] = state
+	}
+}
+
+// getCoopKeyboardState moves the pieces of both players on a shared board - player 1 plays on the left with WASD, player 2 on the right with the arrow keys
+func getCoopKeyboardState(c *coopscreen.CoopScreen) {
+
+	// Pause the game whenever the window loses keyboard focus
+	if sdl.GetKeyboardFocus() != window {
+		c.Pause()
+		return
+	}
+
+	// Escape and P pause and unpause the game
+	if KeyDownOnce(sdl.SCANCODE_ESCAPE) || KeyDownOnce(sdl.SCANCODE_P) {
+		if c.Paused == true {
+			c.Resume()
+		} else {
+			c.Pause()
+		}
+	} else {
+		getPlayerKeys(c.MoveActiveBlock)
+	}
+
+	for i, state := range keyboardState {
+		prevKeyboardState[i] = state
+	}
+}
+
+// getPlayerKeys passes the moves of both players sharing the keyboard to 'move' - player 1 uses WASD, player 2 the arrow keys
+func getPlayerKeys(move func(k int, d string)) {
+	// Player 1
+	if KeyDownOnce(sdl.SCANCODE_W) {
+		move(0, "rotate_cw")
+	}
+	if KeyDownOnce(sdl.SCANCODE_Q) {
+		move(0, "rotate_ccw")
+	}
+	if KeyDownOnce(sdl.SCANCODE_S) {
+		move(0, "down")
+	}
+	if KeyDownOnce(sdl.SCANCODE_A) {
+		move(0, "left")
+	}
+	if KeyDownOnce(sdl.SCANCODE_D) {
+		move(0, "right")
+	}
+	if KeyDownOnce(sdl.SCANCODE_SPACE) {
+		move(0, "drop")
+	}
+	if KeyDownOnce(sdl.SCANCODE_E) {
+		move(0, "hold")
+	}
+
+	// Player 2
+	if KeyDownOnce(sdl.SCANCODE_UP) {
+		move(1, "rotate_cw")
+	}
+	if KeyDownOnce(sdl.SCANCODE_RCTRL) {
+		move(1, "rotate_ccw")
+	}
+	if KeyDownOnce(sdl.SCANCODE_DOWN) {
+		move(1, "down")
+	}
+	if KeyDownOnce(sdl.SCANCODE_LEFT) {
+		move(1, "left")
+	}
+	if KeyDownOnce(sdl.SCANCODE_RIGHT) {
+		move(1, "right")
+	}
+	if KeyDownOnce(sdl.SCANCODE_RETURN) {
+		move(1, "drop")
+	}
+	if KeyDownOnce(sdl.SCANCODE_RSHIFT) {
+		move(1, "hold")
 	}
 }
 
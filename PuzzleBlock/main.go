@@ -1,14 +1,29 @@
 package main
 
 import (
+	"flag"
+	"golang-games/PuzzleBlock/boardmodel"
+	"golang-games/PuzzleBlock/coopscreen"
+	"golang-games/PuzzleBlock/demoscreen"
 	"golang-games/PuzzleBlock/gameboard"
+	"golang-games/PuzzleBlock/gameoverscreen"
 	"golang-games/PuzzleBlock/gamestate"
 	"golang-games/PuzzleBlock/gamestatetransition"
 	"golang-games/PuzzleBlock/guicontrols"
+	"golang-games/PuzzleBlock/highscores"
+	"golang-games/PuzzleBlock/highscorescreen"
+	"golang-games/PuzzleBlock/modescreen"
 	"golang-games/PuzzleBlock/musicplayer"
+	"golang-games/PuzzleBlock/onlinescreen"
 	"golang-games/PuzzleBlock/optionsscreen"
+	"golang-games/PuzzleBlock/pausescreen"
+	"golang-games/PuzzleBlock/puzzlescreen"
+	"golang-games/PuzzleBlock/replay"
+	"golang-games/PuzzleBlock/setupscreen"
 	"golang-games/PuzzleBlock/soundplayer"
 	"golang-games/PuzzleBlock/titlescreen"
+	"golang-games/PuzzleBlock/versusscreen"
+	"log"
 	"math/rand"
 	"time"
 
@@ -57,6 +72,13 @@ func initRendererAndWindow() (*sdl.Renderer, *sdl.Window) {
 
 func main() {
 
+	// Command line flags
+	replayPath := flag.String("replay", "", "play back a replay file when the game is started")
+	rulesPath := flag.String("rules", "", "play with the match rules in a JSON file instead of the default ones")
+	hostAddress := flag.String("host", "", "host an online versus match on the port of the given address when the game is started")
+	joinAddress := flag.String("join", "", "join the online versus match hosted on the given address when the game is started")
+	flag.Parse()
+
 	// Timing variables
 	var frameStart time.Time
 	var elapsedTime float64
@@ -69,15 +91,52 @@ func main() {
 
 	mouseState := guicontrols.GetMouseState()
 
-	// Set Random Seed
+	// Set Random Seed - the gameboard gets a seed of its own so that its games can be reproduced
 	rand.Seed(time.Now().UTC().UnixNano())
+	gameSeed := rand.Int63()
 
 	// TitleScreen variable
 	var t *titlescreen.TitleScreen
 
+	// DemoScreen variable
+	var demoScreen *demoscreen.DemoScreen
+
 	// OptionsScreen variable
 	var o *optionsscreen.OptionsScreen
 
+	// ModeScreen variable
+	var modeScreen *modescreen.ModeScreen
+
+	// SetupScreen variable
+	var setupScreen *setupscreen.SetupScreen
+
+	// VersusScreen variable
+	var v *versusscreen.VersusScreen
+
+	// OnlineScreen variable
+	var onlineScreen *onlinescreen.OnlineScreen
+
+	// CoopScreen variable
+	var c *coopscreen.CoopScreen
+
+	// PauseScreen variable
+	var p *pausescreen.PauseScreen
+
+	// GameOverScreen variable
+	var gameOverScreen *gameoverscreen.GameOverScreen
+
+	// HighScoreScreen variable
+	var h *highscorescreen.HighScoreScreen
+
+	// PuzzleScreen variable
+	var puzzleScreen *puzzlescreen.PuzzleScreen
+
+	// Load the high score table - a table that can not be read starts out empty
+	scores, err := highscores.LoadFromUserData()
+	if err != nil {
+		log.Println(err)
+	}
+
 	// MusicPlayer variable
 	m := musicplayer.NewMusicPlayer("assets/tune", 4)
 
@@ -88,8 +147,52 @@ func main() {
 	// Initialize GameState
 	gameStateTransition := gamestatetransition.NewGameStateTransition(WinWidth, WinHeight, m, gamestate.StartUp, gamestate.TitleScreen, gamestate.StartUp, 500, renderer)
 
-	// Initialize gameboard
-	g := gameboard.NewGameBoard(WinWidth, WinHeight, WinDepth, gameStateTransition, 19, 10, 7, 12, m, s, renderer)
+	// Initialize gameboard - it starts out laid out for the standard play area
+	numAcross, playAreaStart, playAreaEnd := gameboard.Layout(WinWidth, WinHeight, gameboard.StandardWidth, gameboard.StandardHeight)
+	g := gameboard.NewGameBoard(WinWidth, WinHeight, WinDepth, 0, WinWidth, gameStateTransition, numAcross, gameboard.StandardHeight, playAreaStart, playAreaEnd, gameSeed, m, s, renderer)
+
+	// Load the level table
+	levels, err := boardmodel.LoadLevels("assets/levels.json")
+	if err != nil {
+		panic(err)
+	}
+	g.Levels = levels
+
+	// Load the puzzles and make sure they fit on the gameboard
+	puzzles, err := boardmodel.LoadPuzzles("assets/puzzles")
+	if err != nil {
+		panic(err)
+	}
+	for _, puzzle := range puzzles {
+		err = puzzle.Validate(g.Board.Width, g.Board.Height)
+		if err != nil {
+			panic(err)
+		}
+	}
+	g.Puzzles = puzzles
+
+	// Load the match rules, if any were given
+	if *rulesPath != "" {
+		rules, err := boardmodel.LoadRules(*rulesPath)
+		if err != nil {
+			panic(err)
+		}
+		g.Rules = rules
+	}
+	g.NewGame()
+
+	// Load a replay to watch instead of playing, if one was given
+	if *replayPath != "" {
+		r, err := replay.Load(*replayPath)
+		if err != nil {
+			panic(err)
+		}
+		g.Resize(r.Width, r.Height)
+		err = r.Play(g.Board)
+		if err != nil {
+			panic(err)
+		}
+	}
 
 	// Main game loop
 	for {
@@ -99,6 +202,22 @@ func main() {
 			switch e := event.(type) {
 			case *sdl.QuitEvent:
 				return
+			case *sdl.TextInputEvent:
+				if gameStateTransition.CurrentGameState == gamestate.GameOver {
+					gameOverScreen.TextInput(e.GetText())
+				} else if gameStateTransition.CurrentGameState == gamestate.OnlineSetup {
+					onlineScreen.TextInput(e.GetText())
+				}
+			case *sdl.KeyboardEvent:
+				if e.Type == sdl.KEYDOWN && gameStateTransition.CurrentGameState == gamestate.TitleScreen {
+					t.Wake()
+				} else if e.Type == sdl.KEYDOWN && gameStateTransition.CurrentGameState == gamestate.Demo {
+					demoScreen.Stop()
+				} else if e.Type == sdl.KEYDOWN && gameStateTransition.CurrentGameState == gamestate.GameOver {
+					gameOverScreen.KeyDown(e.Keysym.Scancode)
+				} else if e.Type == sdl.KEYDOWN && gameStateTransition.CurrentGameState == gamestate.OnlineSetup {
+					onlineScreen.KeyDown(e.Keysym.Scancode)
+				}
 			case *sdl.TouchFingerEvent:
 				if e.Type == sdl.FINGERDOWN {
 					//touchX := int(e.X * float32(WinWidth))
@@ -123,14 +242,33 @@ func main() {
 			m.PlayTune(0)
 			s.SetVolume(50)
 			window.SetTitle("Loading..")
-			t = titlescreen.NewTitleScreen(WinWidth, WinHeight, WinDepth, 10, gameStateTransition, mouseState, m, s, renderer)
+			demoScreen = demoscreen.NewDemoScreen(WinWidth, WinHeight, WinDepth, gameStateTransition, mouseState, g, m, s, renderer)
+			t = titlescreen.NewTitleScreen(WinWidth, WinHeight, WinDepth, 10, gameStateTransition, mouseState, g, demoScreen, m, s, renderer)
 			window.SetTitle("Loading...")
-			o = optionsscreen.NewOptionsScreen(WinWidth, WinHeight, WinDepth, gameStateTransition, mouseState, m, s, renderer)
+			o = optionsscreen.NewOptionsScreen(WinWidth, WinHeight, WinDepth, gameStateTransition, mouseState, g, m, s, renderer)
 			window.SetTitle("Loading.")
+			v = versusscreen.NewVersusScreen(WinWidth, WinHeight, WinDepth, gameStateTransition, mouseState, g, m, s, renderer)
+			c = coopscreen.NewCoopScreen(WinWidth, WinHeight, WinDepth, gameStateTransition, mouseState, g, m, s, renderer)
+			setupScreen = setupscreen.NewSetupScreen(WinWidth, WinHeight, WinDepth, gameStateTransition, mouseState, g, m, s, renderer)
+			modeScreen = modescreen.NewModeScreen(WinWidth, WinHeight, WinDepth, gameStateTransition, mouseState, g, v, c, m, s, renderer)
+			onlineScreen = onlinescreen.NewOnlineScreen(WinWidth, WinHeight, WinDepth, gameStateTransition, mouseState, g, v, m, s, renderer)
+			p = pausescreen.NewPauseScreen(WinWidth, WinHeight, gameStateTransition, mouseState, g, m, s, renderer)
+			gameOverScreen = gameoverscreen.NewGameOverScreen(WinWidth, WinHeight, WinDepth, gameStateTransition, mouseState, g, scores, m, s, renderer)
+			h = highscorescreen.NewHighScoreScreen(WinWidth, WinHeight, WinDepth, gameStateTransition, mouseState, scores, m, s, renderer)
+			puzzleScreen = puzzlescreen.NewPuzzleScreen(WinWidth, WinHeight, WinDepth, gameStateTransition, mouseState, g, m, s, renderer)
 			gameStateTransition.TransitioningDown = true
 			window.SetTitle("Loading..")
 			gameStateTransition.CurrentGameState = gamestate.TitleScreen
 			window.SetTitle("PuzzleBlock")
+
+			// Go straight to hosting or joining an online match, if asked to
+			if *hostAddress != "" {
+				onlineScreen.Host(*hostAddress)
+				gameStateTransition.CurrentGameState = gamestate.OnlineSetup
+			} else if *joinAddress != "" {
+				onlineScreen.Join(*joinAddress)
+				gameStateTransition.CurrentGameState = gamestate.OnlineSetup
+			}
 			gameStateTransition.TransitionTimer = 0
 		case gamestate.TitleScreen:
 			// Get Mouse Input
@@ -142,6 +280,21 @@ func main() {
 			t.Update(elapsedTime)
 			t.Draw(renderer)
 
+			// Draw transition
+			if gameStateTransition.TransitioningDown == true || gameStateTransition.TransitioningUp == true {
+				gameStateTransition.Update(elapsedTime)
+				gameStateTransition.Draw(renderer)
+			}
+		case gamestate.Demo:
+			// Get Mouse Input
+			if gameStateTransition.TransitioningDown == false && gameStateTransition.TransitioningUp == false {
+				mouseState.Update()
+			}
+
+			// Draw demoscreen
+			demoScreen.Update(elapsedTime)
+			demoScreen.Draw(renderer)
+
 			// Draw transition
 			if gameStateTransition.TransitioningDown == true || gameStateTransition.TransitioningUp == true {
 				gameStateTransition.Update(elapsedTime)
@@ -157,6 +310,51 @@ func main() {
 			o.Update(elapsedTime)
 			o.Draw(renderer)
 
+			// Draw transition
+			if gameStateTransition.TransitioningDown == true || gameStateTransition.TransitioningUp == true {
+				gameStateTransition.Update(elapsedTime)
+				gameStateTransition.Draw(renderer)
+			}
+		case gamestate.ModeSelect:
+			// Get Mouse Input
+			if gameStateTransition.TransitioningDown == false && gameStateTransition.TransitioningUp == false {
+				mouseState.Update()
+			}
+
+			// Draw modescreen
+			modeScreen.Update(elapsedTime)
+			modeScreen.Draw(renderer)
+
+			// Draw transition
+			if gameStateTransition.TransitioningDown == true || gameStateTransition.TransitioningUp == true {
+				gameStateTransition.Update(elapsedTime)
+				gameStateTransition.Draw(renderer)
+			}
+		case gamestate.GameSetup:
+			// Get Mouse Input
+			if gameStateTransition.TransitioningDown == false && gameStateTransition.TransitioningUp == false {
+				mouseState.Update()
+			}
+
+			// Draw setupscreen
+			setupScreen.Update(elapsedTime)
+			setupScreen.Draw(renderer)
+
+			// Draw transition
+			if gameStateTransition.TransitioningDown == true || gameStateTransition.TransitioningUp == true {
+				gameStateTransition.Update(elapsedTime)
+				gameStateTransition.Draw(renderer)
+			}
+		case gamestate.OnlineSetup:
+			// Get Mouse Input
+			if gameStateTransition.TransitioningDown == false && gameStateTransition.TransitioningUp == false {
+				mouseState.Update()
+			}
+
+			// Draw onlinescreen
+			onlineScreen.Update(elapsedTime)
+			onlineScreen.Draw(renderer)
+
 			// Draw transition
 			if gameStateTransition.TransitioningDown == true || gameStateTransition.TransitioningUp == true {
 				gameStateTransition.Update(elapsedTime)
@@ -165,13 +363,108 @@ func main() {
 		case gamestate.MainGame:
 			// Get Keyboard Input
 			if gameStateTransition.TransitioningDown == false && gameStateTransition.TransitioningUp == false {
-				getKeyboardState(g)
+				getKeyboardState(g, p)
 			}
 
-			// Draw gameboard
-			g.Update(elapsedTime)
+			// Draw gameboard - unless the keyboard has just paused the game
+			if gameStateTransition.CurrentGameState == gamestate.MainGame {
+				g.Update(elapsedTime)
+			}
 			g.Draw(renderer)
 
+			// Draw transition
+			if gameStateTransition.TransitioningDown == true || gameStateTransition.TransitioningUp == true {
+				gameStateTransition.Update(elapsedTime)
+				gameStateTransition.Draw(renderer)
+			}
+		case gamestate.Versus:
+			// Get Mouse and Keyboard Input
+			if gameStateTransition.TransitioningDown == false && gameStateTransition.TransitioningUp == false {
+				mouseState.Update()
+				getVersusKeyboardState(v)
+			}
+
+			// Draw versusscreen
+			v.Update(elapsedTime)
+			v.Draw(renderer)
+
+			// Draw transition
+			if gameStateTransition.TransitioningDown == true || gameStateTransition.TransitioningUp == true {
+				gameStateTransition.Update(elapsedTime)
+				gameStateTransition.Draw(renderer)
+			}
+		case gamestate.Coop:
+			// Get Mouse and Keyboard Input
+			if gameStateTransition.TransitioningDown == false && gameStateTransition.TransitioningUp == false {
+				mouseState.Update()
+				getCoopKeyboardState(c)
+			}
+
+			// Draw coopscreen
+			c.Update(elapsedTime)
+			c.Draw(renderer)
+
+			// Draw transition
+			if gameStateTransition.TransitioningDown == true || gameStateTransition.TransitioningUp == true {
+				gameStateTransition.Update(elapsedTime)
+				gameStateTransition.Draw(renderer)
+			}
+		case gamestate.Paused:
+			// Get Mouse and Keyboard Input
+			if gameStateTransition.TransitioningDown == false && gameStateTransition.TransitioningUp == false {
+				mouseState.Update()
+				getKeyboardState(g, p)
+			}
+
+			// Draw pausescreen over the frozen gameboard
+			p.Update(elapsedTime)
+			p.Draw(renderer)
+
+			// Draw transition
+			if gameStateTransition.TransitioningDown == true || gameStateTransition.TransitioningUp == true {
+				gameStateTransition.Update(elapsedTime)
+				gameStateTransition.Draw(renderer)
+			}
+		case gamestate.GameOver:
+			// Get Mouse Input
+			if gameStateTransition.TransitioningDown == false && gameStateTransition.TransitioningUp == false {
+				mouseState.Update()
+			}
+
+			// Draw gameoverscreen
+			gameOverScreen.Update(elapsedTime)
+			gameOverScreen.Draw(renderer)
+
+			// Draw transition
+			if gameStateTransition.TransitioningDown == true || gameStateTransition.TransitioningUp == true {
+				gameStateTransition.Update(elapsedTime)
+				gameStateTransition.Draw(renderer)
+			}
+		case gamestate.HighScores:
+			// Get Mouse Input
+			if gameStateTransition.TransitioningDown == false && gameStateTransition.TransitioningUp == false {
+				mouseState.Update()
+			}
+
+			// Draw highscorescreen
+			h.Update(elapsedTime)
+			h.Draw(renderer)
+
+			// Draw transition
+			if gameStateTransition.TransitioningDown == true || gameStateTransition.TransitioningUp == true {
+				gameStateTransition.Update(elapsedTime)
+				gameStateTransition.Draw(renderer)
+			}
+		case gamestate.PuzzleResult:
+			// Get Mouse Input
+			if gameStateTransition.TransitioningDown == false && gameStateTransition.TransitioningUp == false {
+				mouseState.Update()
+			}
+
+			// Draw puzzlescreen
+			puzzleScreen.Update(elapsedTime)
+			puzzleScreen.Draw(renderer)
+
 			// Draw transition
 			if gameStateTransition.TransitioningDown == true || gameStateTransition.TransitioningUp == true {
 				gameStateTransition.Update(elapsedTime)
@@ -0,0 +1,239 @@
+package modescreen
+
+import (
+	"golang-games/PuzzleBlock/ai"
+	"golang-games/PuzzleBlock/boardmodel"
+	"golang-games/PuzzleBlock/coopscreen"
+	"golang-games/PuzzleBlock/font"
+	"golang-games/PuzzleBlock/gameboard"
+	"golang-games/PuzzleBlock/gamestate"
+	"golang-games/PuzzleBlock/gamestatetransition"
+	"golang-games/PuzzleBlock/guicontrols"
+	"golang-games/PuzzleBlock/musicplayer"
+	"golang-games/PuzzleBlock/soundplayer"
+	"golang-games/PuzzleBlock/sprite"
+	"golang-games/PuzzleBlock/vec3"
+	"golang-games/PuzzleBlock/versusscreen"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// ModeScreen is a struct that contains all the sprite information for the screen a game mode is picked on
+type ModeScreen struct {
+	CurrentGameState *gamestatetransition.GameStateTransition
+	MouseState       *guicontrols.MouseState
+	MusicPlayer      *musicplayer.MusicPlayer
+	SoundPlayer      *soundplayer.SoundPlayer
+	GameBoard        *gameboard.GameBoard
+	VersusScreen     *versusscreen.VersusScreen
+	CoopScreen       *coopscreen.CoopScreen
+	WinWidth         int
+	WinHeight        int
+	Background       *sprite.Sprite
+	TextFont         *font.TTFFont
+	TitleText        *font.TTFString
+	Modes            []boardmodel.Mode
+	ModeButtons      []*guicontrols.TextButton
+	VersusButton     *guicontrols.TextButton
+	Difficulties     []ai.Difficulty
+	CPUButtons       []*guicontrols.TextButton
+	CoopButton       *guicontrols.TextButton
+	OnlineButton     *guicontrols.TextButton
+	BackButton       *guicontrols.TextButton
+}
+
+// NewModeScreen is a mode screen constructor - 'versusscreen' is where a match against another player or the computer is played, and 'coopscreen' where two players share a board
+func NewModeScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetransition.GameStateTransition, mousestate *guicontrols.MouseState, gameboard *gameboard.GameBoard, versusscreen *versusscreen.VersusScreen, coopscreen *coopscreen.CoopScreen, musicplayer *musicplayer.MusicPlayer, soundplayer *soundplayer.SoundPlayer, renderer *sdl.Renderer) *ModeScreen {
+
+	m := &ModeScreen{}
+
+	m.CurrentGameState = gamestate
+
+	m.MouseState = mousestate
+
+	m.MusicPlayer = musicplayer
+
+	m.SoundPlayer = soundplayer
+
+	m.GameBoard = gameboard
+
+	m.VersusScreen = versusscreen
+
+	m.CoopScreen = coopscreen
+
+	m.WinWidth = winWidth
+	m.WinHeight = winHeight
+
+	// Set the background image
+	m.Background = sprite.NewSprite(
+		"assets/background.png",
+		vec3.Vector3{X: 0, Y: 0, Z: 0},
+		vec3.Vector3{X: 0, Y: 0, Z: 0},
+		1280,
+		720,
+		float64(winWidth)/1280,
+		float64(winHeight)/720,
+		1,
+		1,
+		0,
+		0,
+		true,
+		0,
+		false,
+		renderer)
+
+	// Set the font for the text
+	m.TextFont = font.NewTTFFont("assets/FifteenTwenty-Bold.otf", winWidth, winHeight)
+
+	// Set the title text
+	m.TitleText = font.NewTTFString("Game Mode",
+		font.FontTitle,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: 0, Y: float32(winHeight) * 0.05, Z: 0},
+		m.TextFont,
+		renderer)
+	m.TitleText.SetCenterX()
+
+	// Set a button for every mode down the left of the screen, with the matches against another player or the computer down the right
+	m.Modes = boardmodel.Modes()
+	m.ModeButtons = make([]*guicontrols.TextButton, len(m.Modes))
+	for i := range m.Modes {
+		m.ModeButtons[i] = m.newModeButton("  "+m.Modes[i].Name+"  ", 0.3, i, renderer)
+	}
+
+	m.VersusButton = m.newModeButton("  2P Versus  ", 0.7, 0, renderer)
+
+	m.Difficulties = ai.Difficulties()
+	m.CPUButtons = make([]*guicontrols.TextButton, len(m.Difficulties))
+	for i := range m.Difficulties {
+		m.CPUButtons[i] = m.newModeButton("  vs CPU "+m.Difficulties[i].Name+"  ", 0.7, i+1, renderer)
+	}
+
+	m.CoopButton = m.newModeButton("  2P Co-op  ", 0.7, len(m.Difficulties)+1, renderer)
+
+	m.OnlineButton = m.newModeButton("  Online Versus  ", 0.7, len(m.Difficulties)+2, renderer)
+
+	m.BackButton = guicontrols.NewTextButton(m.WinWidth,
+		m.WinHeight,
+		"   Back   ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(m.WinHeight) * 0.85, Z: 0},
+		0.1,
+		100,
+		m.TextFont,
+		renderer)
+	m.BackButton.SetCenterX()
+
+	return m
+}
+
+// newModeButton returns a button centered on 'x', a fraction of the width of the window, in the 'row'-th row of buttons
+func (m *ModeScreen) newModeButton(text string, x float32, row int, renderer *sdl.Renderer) *guicontrols.TextButton {
+	button := guicontrols.NewTextButton(m.WinWidth,
+		m.WinHeight,
+		text,
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(m.WinHeight) * (0.19 + 0.08*float32(row)), Z: 0},
+		0.1,
+		100,
+		m.TextFont,
+		renderer)
+	button.SetButtonPosition(vec3.Vector3{X: float32(m.WinWidth)*x - float32(button.W/2-button.BorderOffset), Y: button.TextPos.Y, Z: 0})
+	return button
+}
+
+// Update updates all the objects on the mode screen
+func (m *ModeScreen) Update(time float64) {
+
+	// Pick the mode whose button is clicked and go on to set the game up
+	for i := range m.ModeButtons {
+		if m.ModeButtons[i].WasLeftClicked == true && m.CurrentGameState.TransitioningUp == false {
+			m.GameBoard.Mode = m.Modes[i]
+			m.MusicPlayer.FutureTune = 0
+			m.CurrentGameState.TransitioningUp = true
+			m.CurrentGameState.ToState = gamestate.GameSetup
+		}
+	}
+
+	// Start a two player match if the versus button is clicked
+	if m.VersusButton.WasLeftClicked == true && m.CurrentGameState.TransitioningUp == false {
+		m.VersusScreen.CPU = nil
+		m.VersusScreen.Start()
+		m.MusicPlayer.FutureTune = m.MusicPlayer.PastTune
+		m.CurrentGameState.TransitioningUp = true
+		m.CurrentGameState.ToState = gamestate.Versus
+	}
+
+	// Start a match against the computer at the difficulty whose button is clicked
+	for i := range m.CPUButtons {
+		if m.CPUButtons[i].WasLeftClicked == true && m.CurrentGameState.TransitioningUp == false {
+			m.VersusScreen.StartCPU(m.Difficulties[i])
+			m.MusicPlayer.FutureTune = m.MusicPlayer.PastTune
+			m.CurrentGameState.TransitioningUp = true
+			m.CurrentGameState.ToState = gamestate.Versus
+		}
+	}
+
+	// Start a game on a shared board if the co-op button is clicked
+	if m.CoopButton.WasLeftClicked == true && m.CurrentGameState.TransitioningUp == false {
+		m.CoopScreen.Start()
+		m.MusicPlayer.FutureTune = m.MusicPlayer.PastTune
+		m.CurrentGameState.TransitioningUp = true
+		m.CurrentGameState.ToState = gamestate.Coop
+	}
+
+	// Go on to host or join a match over the network if the online button is clicked
+	if m.OnlineButton.WasLeftClicked == true && m.CurrentGameState.TransitioningUp == false {
+		m.CurrentGameState.TransitioningUp = true
+		m.CurrentGameState.ToState = gamestate.OnlineSetup
+	}
+
+	// Return to the title screen if the back button is clicked
+	if m.BackButton.WasLeftClicked == true && m.CurrentGameState.TransitioningUp == false {
+		m.MusicPlayer.FutureTune = 0
+		m.CurrentGameState.TransitioningUp = true
+		m.CurrentGameState.ToState = gamestate.TitleScreen
+	}
+
+	// Update the buttons
+	for i := range m.ModeButtons {
+		m.ModeButtons[i].Update(m.MouseState, time)
+	}
+	m.VersusButton.Update(m.MouseState, time)
+	for i := range m.CPUButtons {
+		m.CPUButtons[i].Update(m.MouseState, time)
+	}
+	m.CoopButton.Update(m.MouseState, time)
+	m.OnlineButton.Update(m.MouseState, time)
+	m.BackButton.Update(m.MouseState, time)
+}
+
+// Draw draws all the objects on the mode screen
+func (m *ModeScreen) Draw(renderer *sdl.Renderer) {
+
+	// Draw the background
+	m.Background.Draw(renderer)
+
+	// Draw the text
+	m.TitleText.Draw(renderer)
+
+	// Draw the buttons
+	for i := range m.ModeButtons {
+		m.ModeButtons[i].Draw(renderer)
+	}
+	m.VersusButton.Draw(renderer)
+	for i := range m.CPUButtons {
+		m.CPUButtons[i].Draw(renderer)
+	}
+	m.CoopButton.Draw(renderer)
+	m.OnlineButton.Draw(renderer)
+	m.BackButton.Draw(renderer)
+}
@@ -0,0 +1,537 @@
+package netplay
+
+import (
+	"encoding/json"
+	"errors"
+	"golang-games/PuzzleBlock/boardmodel"
+	"golang-games/PuzzleBlock/replay"
+	"io"
+	"net"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Version is the version of the network protocol - both players need the same version, and the same replay version so that their games play out the same way
+const Version = 1
+
+// DefaultPort is the port a game is hosted on when the address does not give one
+const DefaultPort = "7777"
+
+// InputDelay is the number of frames between a key being pressed and the move being applied - it gives the move time to reach the other player
+const InputDelay = 10
+
+// CheckInterval is the number of frames between two comparisons of the hashes of the boards
+const CheckInterval = 100
+
+// DialTimeout is how long joining waits for the host to answer
+const DialTimeout = 10 * time.Second
+
+// Types of the messages sent between the two players
+const (
+	// HelloMessage is sent by the host as soon as a player joins, with the settings of the match
+	HelloMessage = "hello"
+	// InputMessage holds the moves a player makes on a frame - one is sent for every frame
+	InputMessage = "input"
+	// GarbageMessage is sent whenever a player's board sends garbage, so that the other player can check that their copy of the board did the same
+	GarbageMessage = "garbage"
+	// CheckMessage holds the hash of the match every CheckInterval frames
+	CheckMessage = "check"
+	// ByeMessage is sent when a player leaves
+	ByeMessage = "bye"
+)
+
+// validMoves are the moves a player can send - 'fall' is left out since boards make it themselves
+var validMoves = map[string]bool{
+	"up":         true,
+	"down":       true,
+	"left":       true,
+	"right":      true,
+	"drop":       true,
+	"hold":       true,
+	"rotate_cw":  true,
+	"rotate_ccw": true,
+}
+
+// Settings are what both players need to start the same match
+type Settings struct {
+	Seed       int64              `json:"seed"`
+	Width      int                `json:"width"`
+	Height     int                `json:"height"`
+	StartLevel int                `json:"start_level"`
+	Pairs      bool               `json:"pairs"`
+	Rules      boardmodel.Rules   `json:"rules"`
+	Levels     []boardmodel.Level `json:"levels"`
+}
+
+// Message is a single line sent over the connection - only the fields of its type are filled in
+type Message struct {
+	Type     string    `json:"type"`
+	Version  int       `json:"version,omitempty"`
+	Replay   int       `json:"replay,omitempty"`
+	Settings *Settings `json:"settings,omitempty"`
+	Frame    int       `json:"frame"`
+	Moves    []string  `json:"moves,omitempty"`
+	Garbage  int       `json:"garbage,omitempty"`
+	Hash     uint64    `json:"hash,omitempty"`
+}
+
+// Status denotes how far along a session is
+type Status int
+
+const (
+	// Connecting is waiting for the other player to join, or for the host to answer
+	Connecting Status = iota
+	// Ready has both players connected and the settings agreed on - the match can be started
+	Ready
+	// Playing is stepping the match in lockstep with the other player
+	Playing
+	// Closed has left the match
+	Closed
+	// Failed has lost the connection or gone out of sync with the other player - Err says why
+	Failed
+)
+
+// Session is one player's end of an online versus match - both players step the same match, each applying their own moves and the ones that come in over the connection on the same frames
+// The host plays board 0 and the player who joins plays board 1
+type Session struct {
+	Status        Status
+	Err           error
+	Host          bool
+	Local         int
+	Settings      Settings
+	Match         *boardmodel.Versus
+	FrameTimer    float64
+	Pending       []string
+	LocalInputs   map[int][]string
+	RemoteInputs  map[int][]string
+	SentFrame     int
+	RemoteFrame   int
+	Checks        map[int]uint64
+	RemoteChecks  map[int]uint64
+	Checked       int
+	Garbage       map[int]int
+	RemoteGarbage map[int]int
+	listener      net.Listener
+	conn          net.Conn
+	encoder       *json.Encoder
+	conns         chan net.Conn
+	messages      chan Message
+	errs          chan error
+	done          chan struct{}
+}
+
+// newSession sets up the parts of a session the host and the player who joins have in common
+func newSession(host bool) *Session {
+
+	s := &Session{}
+
+	s.Status = Connecting
+	s.Host = host
+	s.Local = 1
+	if host == true {
+		s.Local = 0
+	}
+
+	// Moves from the other player can come in before the match has been started
+	s.RemoteInputs = make(map[int][]string)
+	s.RemoteChecks = make(map[int]uint64)
+	s.RemoteGarbage = make(map[int]int)
+	s.RemoteFrame = InputDelay - 1
+
+	s.conns = make(chan net.Conn)
+	s.messages = make(chan Message, 4096)
+	s.errs = make(chan error, 1)
+	s.done = make(chan struct{})
+
+	return s
+}
+
+// Host starts waiting for a player to join on 'address' - the match is played with 'settings'
+func Host(address string, settings Settings) *Session {
+	s := newSession(true)
+	s.Settings = settings
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		s.fail(err)
+		return s
+	}
+	s.listener = listener
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			s.report(err)
+			return
+		}
+		s.hand(conn)
+	}()
+
+	return s
+}
+
+// Join connects to a game hosted on 'address'
+func Join(address string) *Session {
+	s := newSession(false)
+
+	go func() {
+		conn, err := net.DialTimeout("tcp", address, DialTimeout)
+		if err != nil {
+			s.report(err)
+			return
+		}
+		s.hand(conn)
+	}()
+
+	return s
+}
+
+// HostAddress turns what the player typed into an address to listen on - only the port matters, and a missing port is the default one
+func HostAddress(address string) string {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil || port == "" {
+		port = DefaultPort
+	}
+	return ":" + port
+}
+
+// JoinAddress turns what the player typed into an address to connect to, adding the default port if there is none
+func JoinAddress(address string) string {
+	_, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return net.JoinHostPort(address, DefaultPort)
+	}
+	return address
+}
+
+// hand passes a new connection from a background goroutine on to Update, or closes it if the session has been left in the meantime
+func (s *Session) hand(conn net.Conn) {
+	select {
+	case s.conns <- conn:
+	case <-s.done:
+		conn.Close()
+	}
+}
+
+// report passes an error from a background goroutine on to Update - only the first one matters
+func (s *Session) report(err error) {
+	select {
+	case s.errs <- err:
+	default:
+	}
+}
+
+// fail stops the session because of 'err'
+func (s *Session) fail(err error) {
+	if s.Status == Closed || s.Status == Failed {
+		return
+	}
+	s.Err = err
+	s.Status = Failed
+	s.disconnect()
+}
+
+// disconnect closes the connection and stops the background goroutines
+func (s *Session) disconnect() {
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	if s.conn != nil {
+		s.conn.Close()
+	}
+}
+
+// send writes a message to the other player
+func (s *Session) send(m Message) {
+	if s.encoder == nil {
+		return
+	}
+	err := s.encoder.Encode(m)
+	if err != nil {
+		s.fail(err)
+	}
+}
+
+// connect starts talking to the other player over 'conn' - the host sends the settings of the match straight away
+func (s *Session) connect(conn net.Conn) {
+	s.conn = conn
+	s.encoder = json.NewEncoder(conn)
+
+	if s.listener != nil {
+		s.listener.Close()
+		s.listener = nil
+	}
+
+	go func() {
+		decoder := json.NewDecoder(conn)
+		for {
+			var m Message
+			err := decoder.Decode(&m)
+			if err != nil {
+				s.report(err)
+				return
+			}
+			select {
+			case s.messages <- m:
+			case <-s.done:
+				return
+			}
+		}
+	}()
+
+	if s.Host == true {
+		settings := s.Settings
+		s.send(Message{Type: HelloMessage, Version: Version, Replay: replay.Version, Settings: &settings})
+		if s.Status == Connecting {
+			s.Status = Ready
+		}
+	}
+}
+
+// Start starts stepping the match between 'boards', which have to have been started from the settings of the session - the local player plays boards[Local]
+// A board that was not fails the session straight away, rather than going out of sync a few frames in - the match is still set up so that it can be shown
+func (s *Session) Start(boards []*boardmodel.Board) {
+	s.Match = boardmodel.NewVersus(boards...)
+	for k, b := range boards {
+		if s.started(b) == false {
+			s.fail(errors.New("netplay: board " + strconv.Itoa(k) + " was not started from the settings of the match"))
+			return
+		}
+	}
+
+	s.FrameTimer = 0
+	s.Pending = nil
+	s.LocalInputs = make(map[int][]string)
+	s.Checks = make(map[int]uint64)
+	s.Garbage = make(map[int]int)
+	s.Checked = 0
+	s.SentFrame = InputDelay - 1
+
+	s.Status = Playing
+}
+
+// started returns true if 'b' has the size, starting level and rules of the settings of the session
+func (s *Session) started(b *boardmodel.Board) bool {
+	return b.Width == s.Settings.Width &&
+		b.Height == s.Settings.Height &&
+		b.StartLevel == s.Settings.StartLevel &&
+		b.PairMode == s.Settings.Pairs &&
+		b.Rules == s.Settings.Rules &&
+		reflect.DeepEqual(b.Levels, s.Settings.Levels) == true
+}
+
+// QueueMove sends a move of the local player - it is applied InputDelay frames from now on both ends
+func (s *Session) QueueMove(d string) {
+	if s.Status == Playing && s.Match.Over == false {
+		s.Pending = append(s.Pending, d)
+	}
+}
+
+// Close leaves the match and lets the other player know
+func (s *Session) Close() {
+	if s.Status == Closed || s.Status == Failed {
+		return
+	}
+	s.send(Message{Type: ByeMessage})
+	s.Status = Closed
+	s.disconnect()
+}
+
+// Update handles everything the other player has sent and steps the match as far as their moves allow, by up to 'time' milliseconds
+func (s *Session) Update(time float64) {
+	for s.Status != Closed && s.Status != Failed {
+		select {
+		case conn := <-s.conns:
+			s.connect(conn)
+			continue
+		case err := <-s.errs:
+			// The connection closing once the match is over is nothing to worry about
+			if s.Match != nil && s.Match.Over == true {
+				s.Status = Closed
+				s.disconnect()
+			} else if err == io.EOF {
+				s.fail(errors.New("netplay: lost the connection to the other player"))
+			} else {
+				s.fail(err)
+			}
+			continue
+		case m := <-s.messages:
+			s.handle(m)
+			continue
+		default:
+		}
+		break
+	}
+
+	if s.Status != Playing {
+		return
+	}
+
+	s.FrameTimer += time
+	if s.FrameTimer > boardmodel.MaxFrameBacklog {
+		s.FrameTimer = boardmodel.MaxFrameBacklog
+	}
+
+	for s.FrameTimer >= boardmodel.FrameTime && s.Match.Over == false && s.Status == Playing {
+		frame := s.Match.Frame()
+
+		// Send the moves made since the last frame, to be applied InputDelay frames from now
+		if s.SentFrame < frame+InputDelay {
+			s.SentFrame = frame + InputDelay
+			s.LocalInputs[s.SentFrame] = s.Pending
+			s.send(Message{Type: InputMessage, Frame: s.SentFrame, Moves: s.Pending})
+			s.Pending = nil
+		}
+
+		// Wait for the other player to catch up
+		if frame > s.RemoteFrame {
+			break
+		}
+
+		s.FrameTimer -= boardmodel.FrameTime
+		s.step(frame)
+	}
+}
+
+// Waiting returns true if the match is held up by moves from the other player that have not come in yet
+func (s *Session) Waiting() bool {
+	return s.Status == Playing && s.Match.Over == false && s.Match.Frame() > s.RemoteFrame
+}
+
+// step applies the moves of both players for 'frame' and steps the match, then checks that the other player's copy of the match did the same
+func (s *Session) step(frame int) {
+	remote := 1 - s.Local
+
+	for _, d := range s.LocalInputs[frame] {
+		s.Match.Boards[s.Local].MoveActiveBlock(d)
+	}
+	for _, d := range s.RemoteInputs[frame] {
+		s.Match.Boards[remote].MoveActiveBlock(d)
+	}
+	delete(s.LocalInputs, frame)
+	delete(s.RemoteInputs, frame)
+
+	s.Match.Step()
+
+	// Tell the other player about any garbage the local board sent, and compare the garbage the other board sent with what they said it did
+	if s.Match.Sent[s.Local] > 0 {
+		s.send(Message{Type: GarbageMessage, Frame: frame, Garbage: s.Match.Sent[s.Local]})
+	}
+	if sent, ok := s.RemoteGarbage[frame]; ok == true {
+		s.compareGarbage(frame, s.Match.Sent[remote], sent)
+		delete(s.RemoteGarbage, frame)
+	} else if s.Match.Sent[remote] > 0 {
+		s.Garbage[frame] = s.Match.Sent[remote]
+	}
+
+	// Compare the hashes of the match every so often
+	if (frame+1)%CheckInterval == 0 {
+		hash := s.Match.Hash()
+		s.send(Message{Type: CheckMessage, Frame: frame, Hash: hash})
+		if remoteHash, ok := s.RemoteChecks[frame]; ok == true {
+			s.compareHash(frame, hash, remoteHash)
+			delete(s.RemoteChecks, frame)
+		} else {
+			s.Checks[frame] = hash
+		}
+	}
+}
+
+// handle deals with a single message from the other player
+func (s *Session) handle(m Message) {
+	stepped := s.Match != nil && m.Frame < s.Match.Frame()
+
+	switch m.Type {
+	case HelloMessage:
+		if s.Host == true || s.Status != Connecting || m.Settings == nil {
+			s.fail(errors.New("netplay: unexpected hello"))
+			return
+		}
+		if m.Version != Version || m.Replay != replay.Version {
+			s.fail(errors.New("netplay: the host is running a different version of the game"))
+			return
+		}
+		err := m.Settings.Rules.Validate()
+		if err == nil {
+			err = boardmodel.ValidateLevels(m.Settings.Levels)
+		}
+		if err == nil && (m.Settings.Width < 1 || m.Settings.Height < 1) {
+			err = errors.New("netplay: the host sent a board without a size")
+		}
+		if err == nil && (m.Settings.StartLevel < 1 || m.Settings.StartLevel > len(m.Settings.Levels)) {
+			err = errors.New("netplay: the host sent a starting level that is not in the level table")
+		}
+		if err != nil {
+			s.fail(err)
+			return
+		}
+		s.Settings = *m.Settings
+		s.Status = Ready
+	case InputMessage:
+		if m.Frame != s.RemoteFrame+1 {
+			s.fail(errors.New("netplay: expected moves for frame " + strconv.Itoa(s.RemoteFrame+1) + ", got frame " + strconv.Itoa(m.Frame)))
+			return
+		}
+		for _, d := range m.Moves {
+			if validMoves[d] == false {
+				s.fail(errors.New("netplay: unknown move \"" + d + "\""))
+				return
+			}
+		}
+		s.RemoteInputs[m.Frame] = m.Moves
+		s.RemoteFrame = m.Frame
+
+		// The other player sends the garbage of a frame before the moves for InputDelay frames on, so garbage they sent no word of by now never happened on their end
+		for frame, sent := range s.Garbage {
+			if frame < m.Frame-InputDelay {
+				s.compareGarbage(frame, sent, 0)
+				delete(s.Garbage, frame)
+			}
+		}
+	case GarbageMessage:
+		if stepped == true {
+			s.compareGarbage(m.Frame, s.Garbage[m.Frame], m.Garbage)
+			delete(s.Garbage, m.Frame)
+		} else {
+			s.RemoteGarbage[m.Frame] = m.Garbage
+		}
+	case CheckMessage:
+		if stepped == true {
+			s.compareHash(m.Frame, s.Checks[m.Frame], m.Hash)
+			delete(s.Checks, m.Frame)
+		} else {
+			s.RemoteChecks[m.Frame] = m.Hash
+		}
+	case ByeMessage:
+		if s.Match != nil && s.Match.Over == true {
+			s.Status = Closed
+			s.disconnect()
+		} else {
+			s.fail(errors.New("netplay: the other player left"))
+		}
+	default:
+		s.fail(errors.New("netplay: unknown message \"" + m.Type + "\""))
+	}
+}
+
+// compareGarbage fails the session if the two ends disagree on the garbage sent on 'frame'
+func (s *Session) compareGarbage(frame, local, remote int) {
+	if local != remote {
+		s.fail(errors.New("netplay: out of sync on frame " + strconv.Itoa(frame) + " - the garbage sent does not match"))
+	}
+}
+
+// compareHash fails the session if the two ends disagree on the state of the match after 'frame'
+func (s *Session) compareHash(frame int, local, remote uint64) {
+	if local != remote {
+		s.fail(errors.New("netplay: out of sync on frame " + strconv.Itoa(frame) + " - the boards do not match"))
+		return
+	}
+	s.Checked++
+}
@@ -0,0 +1,246 @@
+package netplay
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testTimeout is how long a test waits for the two ends of a match before giving up
+const testTimeout = 30 * time.Second
+
+// testMoves are queued in turn by both players, each on frames of their own
+var testMoves = []string{"left", "rotate_cw", "right", "right", "down", "hold", "left", "drop"}
+
+// connectPair hosts a match on a free port of this computer, joins it and waits until both ends are ready
+func connectPair(t *testing.T, settings Settings) (*Session, *Session) {
+	host := Host("127.0.0.1:0", settings)
+	if host.Status == Failed {
+		t.Fatal(host.Err)
+	}
+	join := Join(host.listener.Addr().String())
+
+	deadline := time.Now().Add(testTimeout)
+	for host.Status != Ready || join.Status != Ready {
+		host.Update(0)
+		join.Update(0)
+		if host.Status == Failed || join.Status == Failed {
+			t.Fatalf("connecting failed - host: %v, join: %v", host.Err, join.Err)
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out connecting")
+		}
+		runtime.Gosched()
+	}
+
+	if join.Settings.Seed != settings.Seed || join.Settings.Width != settings.Width || join.Settings.Pairs != settings.Pairs {
+		t.Fatalf("joined with settings %+v, expected %+v", join.Settings, settings)
+	}
+
+	return host, join
+}
+
+// startBoards starts the boards of both players from 'seed', the way the versus screen does
+func startBoards(settings Settings, seed int64) []*boardmodel.Board {
+	boards := make([]*boardmodel.Board, 2)
+	for k := range boards {
+		boards[k] = boardmodel.NewBoard(settings.Width, settings.Height, seed)
+		boards[k].PairMode = settings.Pairs
+		boards[k].Rules = settings.Rules
+		boards[k].Levels = settings.Levels
+		boards[k].StartLevel = settings.StartLevel
+		boards[k].StartRecording(seed)
+	}
+	return boards
+}
+
+// playUntil steps both ends of a match with scripted moves until both reach 'frames' frames, the match is over or either end fails
+func playUntil(t *testing.T, host, join *Session, frames int) {
+	deadline := time.Now().Add(testTimeout)
+	for host.Status == Playing && join.Status == Playing && (host.Match.Frame() < frames || join.Match.Frame() < frames) && host.Match.Over == false {
+		for k, s := range []*Session{host, join} {
+			frame := s.Match.Frame()
+			if frame < frames && frame%(11+2*k) == 0 {
+				s.QueueMove(testMoves[(frame/(11+2*k)+k)%len(testMoves)])
+			}
+			// Time only passes while an end is not waiting, so that neither steps more than a frame at once and both stop on 'frames'
+			if frame < frames && s.Waiting() == false {
+				s.Update(boardmodel.FrameTime)
+			} else {
+				s.Update(0)
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out on frames %d and %d", host.Match.Frame(), join.Match.Frame())
+		}
+		runtime.Gosched()
+	}
+}
+
+// drain handles what is left to come in from the other player, without stepping the match on, until 'done' returns true
+func drain(t *testing.T, host, join *Session, done func() bool) {
+	host.FrameTimer = 0
+	join.FrameTimer = 0
+
+	deadline := time.Now().Add(testTimeout)
+	for done() == false {
+		host.Update(0)
+		join.Update(0)
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for the other player")
+		}
+		runtime.Gosched()
+	}
+}
+
+func TestMatchOverLocalhost(t *testing.T) {
+	for _, pairs := range []bool{false, true} {
+		settings := Settings{Seed: 99, Width: 5, Height: 10, StartLevel: 1, Pairs: pairs, Rules: boardmodel.DefaultRules(), Levels: boardmodel.DefaultLevels()}
+		host, join := connectPair(t, settings)
+
+		host.Start(startBoards(host.Settings, host.Settings.Seed))
+		join.Start(startBoards(join.Settings, join.Settings.Seed))
+
+		frames := 10 * CheckInterval
+		playUntil(t, host, join, frames)
+
+		// Every check of the frames stepped on both ends has to have been compared once the last messages are in
+		checks := host.Match.Frame() / CheckInterval
+		drain(t, host, join, func() bool {
+			return (host.Checked >= checks && join.Checked >= checks) || host.Status == Failed || join.Status == Failed
+		})
+
+		if host.Status == Failed || join.Status == Failed {
+			t.Fatalf("pairs %v: match failed - host: %v, join: %v", pairs, host.Err, join.Err)
+		}
+		if host.Match.Frame() != join.Match.Frame() {
+			t.Fatalf("pairs %v: the ends stopped on frames %d and %d", pairs, host.Match.Frame(), join.Match.Frame())
+		}
+		if host.Match.Over == false && host.Match.Frame() < frames {
+			t.Errorf("pairs %v: the match stopped on frame %d before it was over", pairs, host.Match.Frame())
+		}
+		if checks < 1 {
+			t.Errorf("pairs %v: the match ended on frame %d before the first check", pairs, host.Match.Frame())
+		}
+		if host.Checked != checks || join.Checked != checks {
+			t.Errorf("pairs %v: checked the hashes %d and %d times in %d frames, expected %d", pairs, host.Checked, join.Checked, host.Match.Frame(), checks)
+		}
+		if host.Match.Hash() != join.Match.Hash() {
+			t.Errorf("pairs %v: the matches ended with hashes %x and %x", pairs, host.Match.Hash(), join.Match.Hash())
+		}
+		if len(host.Match.Boards[0].Inputs) == 0 || len(join.Match.Boards[1].Inputs) == 0 {
+			t.Errorf("pairs %v: the scripted moves were never applied", pairs)
+		}
+
+		host.Close()
+		join.Close()
+	}
+}
+
+func TestDesyncIsReported(t *testing.T) {
+	settings := Settings{Seed: 5, Width: 5, Height: 10, StartLevel: 1, Pairs: false, Rules: boardmodel.DefaultRules(), Levels: boardmodel.DefaultLevels()}
+	host, join := connectPair(t, settings)
+
+	// The player who joins deals from another seed, so the first check has to catch it
+	host.Start(startBoards(host.Settings, host.Settings.Seed))
+	join.Start(startBoards(join.Settings, join.Settings.Seed+1))
+
+	playUntil(t, host, join, 2*CheckInterval)
+	drain(t, host, join, func() bool {
+		return host.Status == Failed && join.Status == Failed
+	})
+
+	// The end that finds the desync first hangs up, so the other one may only see the connection go
+	outOfSync := false
+	for _, s := range []*Session{host, join} {
+		if s.Status != Failed {
+			t.Errorf("host %v: ended with status %d, expected it to fail", s.Host, s.Status)
+		}
+		if s.Err != nil && strings.Contains(s.Err.Error(), "out of sync") == true {
+			outOfSync = true
+		}
+		if s.Checked != 0 {
+			t.Errorf("host %v: agreed on %d checks of boards that differ", s.Host, s.Checked)
+		}
+	}
+	if outOfSync == false {
+		t.Errorf("neither end reported going out of sync - host: %v, join: %v", host.Err, join.Err)
+	}
+}
+
+func TestBoardsFromOtherSettingsAreRejected(t *testing.T) {
+	settings := Settings{Seed: 5, Width: 5, Height: 10, StartLevel: 1, Pairs: false, Rules: boardmodel.DefaultRules(), Levels: boardmodel.DefaultLevels()}
+
+	tests := []struct {
+		name   string
+		change func(local *Settings)
+	}{
+		{"a wider board", func(local *Settings) { local.Width = 7 }},
+		{"a shorter board", func(local *Settings) { local.Height = 8 }},
+		{"pairs", func(local *Settings) { local.Pairs = true }},
+		{"other rules", func(local *Settings) { local.Rules.MinMatch = 4 }},
+		{"other levels", func(local *Settings) { local.Levels = local.Levels[:1] }},
+		{"a later starting level", func(local *Settings) { local.StartLevel = 3 }},
+	}
+
+	for _, test := range tests {
+		host, join := connectPair(t, settings)
+
+		// The player who joins starts from a setup of their own rather than the host's
+		local := join.Settings
+		test.change(&local)
+		host.Start(startBoards(host.Settings, host.Settings.Seed))
+		join.Start(startBoards(local, local.Seed))
+
+		if join.Status != Failed {
+			t.Errorf("%s: started with status %d, expected it to fail", test.name, join.Status)
+		} else if strings.Contains(join.Err.Error(), "settings of the match") == false {
+			t.Errorf("%s: failed with %v, expected the boards to be rejected", test.name, join.Err)
+		}
+		if join.Match == nil {
+			t.Errorf("%s: no match was set up for the versus screen to show", test.name)
+		}
+		if host.Status != Playing {
+			t.Errorf("%s: the host has status %d, expected it to be playing", test.name, host.Status)
+		}
+
+		host.Close()
+		join.Close()
+	}
+}
+
+func TestUnconfirmedGarbageIsCleanedUp(t *testing.T) {
+	settings := Settings{Seed: 99, Width: 5, Height: 10, StartLevel: 1, Pairs: false, Rules: boardmodel.DefaultRules(), Levels: boardmodel.DefaultLevels()}
+	host, join := connectPair(t, settings)
+
+	host.Start(startBoards(host.Settings, host.Settings.Seed))
+	join.Start(startBoards(join.Settings, join.Settings.Seed))
+
+	playUntil(t, host, join, 3*CheckInterval)
+	drain(t, host, join, func() bool {
+		return host.RemoteFrame >= host.Match.Frame()+InputDelay-1 && join.RemoteFrame >= join.Match.Frame()+InputDelay-1
+	})
+
+	for _, s := range []*Session{host, join} {
+		if s.Status == Failed {
+			t.Fatalf("host %v: match failed: %v", s.Host, s.Err)
+		}
+		if len(s.Garbage) != 0 || len(s.RemoteGarbage) != 0 {
+			t.Errorf("host %v: left %d and %d frames of garbage to compare", s.Host, len(s.Garbage), len(s.RemoteGarbage))
+		}
+	}
+
+	// Garbage the other player never reports is a desync once their moves for later frames come in
+	host.Garbage[0] = 2
+	host.handle(Message{Type: InputMessage, Frame: host.RemoteFrame + 1})
+	if host.Status != Failed || strings.Contains(host.Err.Error(), "garbage") == false {
+		t.Errorf("ended with status %d and %v, expected the unreported garbage to fail the match", host.Status, host.Err)
+	}
+	if len(host.Garbage) != 0 {
+		t.Errorf("kept %d frames of garbage after comparing them", len(host.Garbage))
+	}
+
+	join.Close()
+}
@@ -0,0 +1,297 @@
+package onlinescreen
+
+import (
+	"golang-games/PuzzleBlock/font"
+	"golang-games/PuzzleBlock/gameboard"
+	"golang-games/PuzzleBlock/gamestate"
+	"golang-games/PuzzleBlock/gamestatetransition"
+	"golang-games/PuzzleBlock/guicontrols"
+	"golang-games/PuzzleBlock/musicplayer"
+	"golang-games/PuzzleBlock/netplay"
+	"golang-games/PuzzleBlock/soundplayer"
+	"golang-games/PuzzleBlock/sprite"
+	"golang-games/PuzzleBlock/vec3"
+	"golang-games/PuzzleBlock/versusscreen"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// OnlineScreen is a struct that contains all the sprite information for the screen an online versus match is hosted or joined on
+type OnlineScreen struct {
+	CurrentGameState *gamestatetransition.GameStateTransition
+	MouseState       *guicontrols.MouseState
+	MusicPlayer      *musicplayer.MusicPlayer
+	SoundPlayer      *soundplayer.SoundPlayer
+	GameBoard        *gameboard.GameBoard
+	VersusScreen     *versusscreen.VersusScreen
+	Session          *netplay.Session
+	WinWidth         int
+	WinHeight        int
+	Background       *sprite.Sprite
+	TextFont         *font.TTFFont
+	TitleText        *font.TTFString
+	AddressText      *font.TTFString
+	AddressInput     *guicontrols.TextInput
+	Status           string
+	PrevStatus       string
+	StatusText       *font.TTFString
+	HostButton       *guicontrols.TextButton
+	JoinButton       *guicontrols.TextButton
+	BackButton       *guicontrols.TextButton
+}
+
+// NewOnlineScreen is an online screen constructor - the host's match is played under the settings of 'gameboard', on 'versusscreen'
+func NewOnlineScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetransition.GameStateTransition, mousestate *guicontrols.MouseState, gameboard *gameboard.GameBoard, versusscreen *versusscreen.VersusScreen, musicplayer *musicplayer.MusicPlayer, soundplayer *soundplayer.SoundPlayer, renderer *sdl.Renderer) *OnlineScreen {
+
+	o := &OnlineScreen{}
+
+	o.CurrentGameState = gamestate
+
+	o.MouseState = mousestate
+
+	o.MusicPlayer = musicplayer
+
+	o.SoundPlayer = soundplayer
+
+	o.GameBoard = gameboard
+
+	o.VersusScreen = versusscreen
+
+	o.WinWidth = winWidth
+	o.WinHeight = winHeight
+
+	// Set the background image
+	o.Background = sprite.NewSprite(
+		"assets/background.png",
+		vec3.Vector3{X: 0, Y: 0, Z: 0},
+		vec3.Vector3{X: 0, Y: 0, Z: 0},
+		1280,
+		720,
+		float64(winWidth)/1280,
+		float64(winHeight)/720,
+		1,
+		1,
+		0,
+		0,
+		true,
+		0,
+		false,
+		renderer)
+
+	// Set the font for the text
+	o.TextFont = font.NewTTFFont("assets/FifteenTwenty-Bold.otf", winWidth, winHeight)
+
+	// Set the title text
+	o.TitleText = font.NewTTFString("Online",
+		font.FontTitle,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: 0, Y: float32(winHeight) * 0.05, Z: 0},
+		o.TextFont,
+		renderer)
+	o.TitleText.SetCenterX()
+
+	// Set the box the address is typed into - hosting only looks at the port
+	o.AddressText = font.NewTTFString("Address to join, or port to host on:",
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: 0, Y: float32(o.WinHeight) * 0.26, Z: 0},
+		o.TextFont,
+		renderer)
+	o.AddressText.SetCenterX()
+
+	o.AddressInput = guicontrols.NewTextInput(o.WinWidth,
+		o.WinHeight,
+		40,
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		vec3.Vector3{X: 0, Y: float32(o.WinHeight) * 0.32, Z: 0},
+		int(float32(o.WinWidth)*0.5),
+		500,
+		o.TextFont,
+		renderer)
+	o.AddressInput.SetCenterX()
+	o.AddressInput.Value = "127.0.0.1:" + netplay.DefaultPort
+
+	// Set the text that says how hosting or joining is going
+	o.Status = " "
+	o.PrevStatus = " "
+	o.StatusText = font.NewTTFString(" ",
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 0, A: 255},
+		vec3.Vector3{X: 0, Y: float32(o.WinHeight) * 0.42, Z: 0},
+		o.TextFont,
+		renderer)
+
+	o.HostButton = guicontrols.NewTextButton(o.WinWidth,
+		o.WinHeight,
+		"   Host   ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(o.WinHeight) * 0.52, Z: 0},
+		0.1,
+		100,
+		o.TextFont,
+		renderer)
+	o.HostButton.SetCenterX()
+
+	o.JoinButton = guicontrols.NewTextButton(o.WinWidth,
+		o.WinHeight,
+		"   Join   ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(o.WinHeight) * 0.66, Z: 0},
+		0.1,
+		100,
+		o.TextFont,
+		renderer)
+	o.JoinButton.SetCenterX()
+
+	o.BackButton = guicontrols.NewTextButton(o.WinWidth,
+		o.WinHeight,
+		"   Back   ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(o.WinHeight) * 0.85, Z: 0},
+		0.1,
+		100,
+		o.TextFont,
+		renderer)
+	o.BackButton.SetCenterX()
+
+	return o
+}
+
+// Host starts waiting for a player to join on the port of 'address' - the match is played under the settings of the gameboard, on boards the size of the versus screen's
+// Online matches always start from the standard setup, so that a setup picked for a single player game on either end can not put the two boards out of sync
+func (o *OnlineScreen) Host(address string) {
+	o.Leave()
+	o.AddressInput.Value = address
+
+	settings := netplay.Settings{}
+	settings.Seed = o.GameBoard.Board.Rand.Int63()
+	settings.Width = o.VersusScreen.Players[0].Board.Width
+	settings.Height = o.VersusScreen.Players[0].Board.Height
+	settings.StartLevel = gameboard.StandardSetup().StartLevel
+	settings.Pairs = o.GameBoard.PairMode
+	settings.Rules = o.GameBoard.Rules
+	settings.Levels = o.GameBoard.Levels
+
+	address = netplay.HostAddress(address)
+	o.Session = netplay.Host(address, settings)
+	o.Status = "Waiting for a player on port " + address[1:] + "..."
+}
+
+// Join connects to a match hosted on 'address'
+func (o *OnlineScreen) Join(address string) {
+	o.Leave()
+	o.AddressInput.Value = address
+
+	address = netplay.JoinAddress(address)
+	o.Session = netplay.Join(address)
+	o.Status = "Connecting to " + address + "..."
+}
+
+// Leave stops hosting or joining, if either was started
+func (o *OnlineScreen) Leave() {
+	if o.Session != nil {
+		o.Session.Close()
+		o.Session = nil
+	}
+	o.Status = " "
+}
+
+// Update updates all the objects on the online screen
+func (o *OnlineScreen) Update(time float64) {
+
+	// Start the match as soon as both players are connected - the versus screen takes the session over from here
+	if o.Session != nil && o.CurrentGameState.TransitioningUp == false {
+		o.Session.Update(0)
+		switch o.Session.Status {
+		case netplay.Ready:
+			o.VersusScreen.StartOnline(o.Session)
+			o.Session = nil
+			o.Status = " "
+			o.MusicPlayer.FutureTune = o.MusicPlayer.PastTune
+			o.CurrentGameState.TransitioningUp = true
+			o.CurrentGameState.ToState = gamestate.Versus
+		case netplay.Failed:
+			o.Status = o.Session.Err.Error()
+			o.Session = nil
+		}
+	}
+
+	// Host or join if the buttons are clicked
+	if o.HostButton.WasLeftClicked == true && o.CurrentGameState.TransitioningUp == false {
+		o.Host(o.AddressInput.Value)
+	}
+	if o.JoinButton.WasLeftClicked == true && o.CurrentGameState.TransitioningUp == false {
+		o.Join(o.AddressInput.Value)
+	}
+
+	// Return to the mode screen if the back button is clicked
+	if o.BackButton.WasLeftClicked == true && o.CurrentGameState.TransitioningUp == false {
+		o.Leave()
+		o.CurrentGameState.TransitioningUp = true
+		o.CurrentGameState.ToState = gamestate.ModeSelect
+	}
+
+	// Update the address box
+	o.AddressInput.Update(time)
+
+	// Update the buttons
+	o.HostButton.Update(o.MouseState, time)
+	o.JoinButton.Update(o.MouseState, time)
+	o.BackButton.Update(o.MouseState, time)
+}
+
+// TextInput passes text typed on the keyboard to the address box
+func (o *OnlineScreen) TextInput(text string) {
+	o.AddressInput.AppendText(text)
+}
+
+// KeyDown handles the keys that edit the address box - return joins the address typed in
+func (o *OnlineScreen) KeyDown(key sdl.Scancode) {
+	switch key {
+	case sdl.SCANCODE_BACKSPACE:
+		o.AddressInput.Backspace()
+	case sdl.SCANCODE_RETURN:
+		if o.CurrentGameState.TransitioningUp == false {
+			o.Join(o.AddressInput.Value)
+		}
+	}
+}
+
+// Draw draws all the objects on the online screen
+func (o *OnlineScreen) Draw(renderer *sdl.Renderer) {
+
+	// Draw the background
+	o.Background.Draw(renderer)
+
+	// Change the status text when it changes
+	if o.Status != o.PrevStatus {
+		o.StatusText.ChangeStringTexture(o.Status, font.FontMedium, sdl.Color{R: 255, G: 255, B: 0, A: 255}, renderer)
+		o.StatusText.SetCenterX()
+		o.PrevStatus = o.Status
+	}
+
+	// Draw the text
+	o.TitleText.Draw(renderer)
+	o.AddressText.Draw(renderer)
+	o.AddressInput.Draw(renderer)
+	o.StatusText.Draw(renderer)
+
+	// Draw the buttons
+	o.HostButton.Draw(renderer)
+	o.JoinButton.Draw(renderer)
+	o.BackButton.Draw(renderer)
+}
@@ -1,7 +1,9 @@
 package optionsscreen
 
 import (
+	"golang-games/PuzzleBlock/boardmodel"
 	"golang-games/PuzzleBlock/font"
+	"golang-games/PuzzleBlock/gameboard"
 	"golang-games/PuzzleBlock/gamestate"
 	"golang-games/PuzzleBlock/gamestatetransition"
 	"golang-games/PuzzleBlock/guicontrols"
@@ -21,6 +23,7 @@ type OptionsScreen struct {
 	MouseState            *guicontrols.MouseState
 	MusicPlayer           *musicplayer.MusicPlayer
 	SoundPlayer           *soundplayer.SoundPlayer
+	GameBoard             *gameboard.GameBoard
 	WinWidth              int
 	WinHeight             int
 	Background            *sprite.Sprite
@@ -43,11 +46,21 @@ type OptionsScreen struct {
 	MusicVolumeValueText  *font.TTFString
 	MusicVolumeUpButton   *guicontrols.SpriteButton
 	MusicVolumeDownButton *guicontrols.SpriteButton
+	PreviousPreviewLength int
+	PreviewText           *font.TTFString
+	PreviewValueText      *font.TTFString
+	PreviewUpButton       *guicontrols.SpriteButton
+	PreviewDownButton     *guicontrols.SpriteButton
+	PreviousPairMode      bool
+	PairsText             *font.TTFString
+	PairsValueText        *font.TTFString
+	PairsUpButton         *guicontrols.SpriteButton
+	PairsDownButton       *guicontrols.SpriteButton
 	BackButton            *guicontrols.TextButton
 }
 
 // NewOptionsScreen is an options screen constructor
-func NewOptionsScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetransition.GameStateTransition, mousestate *guicontrols.MouseState, musicplayer *musicplayer.MusicPlayer, soundplayer *soundplayer.SoundPlayer, renderer *sdl.Renderer) *OptionsScreen {
+func NewOptionsScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetransition.GameStateTransition, mousestate *guicontrols.MouseState, gameboard *gameboard.GameBoard, musicplayer *musicplayer.MusicPlayer, soundplayer *soundplayer.SoundPlayer, renderer *sdl.Renderer) *OptionsScreen {
 
 	o := &OptionsScreen{}
 
@@ -59,6 +72,8 @@ func NewOptionsScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetra
 
 	o.SoundPlayer = soundplayer
 
+	o.GameBoard = gameboard
+
 	o.WinWidth = winWidth
 	o.WinHeight = winHeight
 
@@ -98,7 +113,7 @@ func NewOptionsScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetra
 	o.InGameTuneText = font.NewTTFString("In-Game Music",
 		font.FontLarge,
 		sdl.Color{R: 255, G: 255, B: 255, A: 255},
-		vec3.Vector3{X: float32(o.WinWidth) * 0.35, Y: float32(o.WinHeight) * 0.31, Z: 0},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.35, Y: float32(o.WinHeight) * 0.25, Z: 0},
 		o.TextFont,
 		renderer)
 
@@ -106,7 +121,7 @@ func NewOptionsScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetra
 	o.InGameTuneValueText = font.NewTTFString("Music "+strconv.Itoa(o.MusicPlayer.CurrentTune),
 		font.FontMedium,
 		sdl.Color{R: 255, G: 255, B: 255, A: 255},
-		vec3.Vector3{X: float32(o.WinWidth) * 0.115, Y: float32(o.WinHeight) * 0.33, Z: 0},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.115, Y: float32(o.WinHeight) * 0.27, Z: 0},
 		o.TextFont,
 		renderer)
 
@@ -116,7 +131,7 @@ func NewOptionsScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetra
 		sdl.Color{R: 128, G: 128, B: 128, A: 192},
 		sdl.Color{R: 128, G: 128, B: 192, A: 192},
 		sdl.Color{R: 0, G: 0, B: 255, A: 192},
-		vec3.Vector3{X: float32(o.WinWidth) * 0.25, Y: float32(o.WinHeight) * 0.31, Z: 0},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.25, Y: float32(o.WinHeight) * 0.25, Z: 0},
 		0.1,
 		100,
 		64,
@@ -131,7 +146,7 @@ func NewOptionsScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetra
 		sdl.Color{R: 128, G: 128, B: 128, A: 192},
 		sdl.Color{R: 128, G: 128, B: 192, A: 192},
 		sdl.Color{R: 0, G: 0, B: 255, A: 192},
-		vec3.Vector3{X: float32(o.WinWidth) * 0.05, Y: float32(o.WinHeight) * 0.31, Z: 0},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.05, Y: float32(o.WinHeight) * 0.25, Z: 0},
 		0.1,
 		100,
 		64,
@@ -147,7 +162,7 @@ func NewOptionsScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetra
 	o.SoundVolumeText = font.NewTTFString("Sound Volume",
 		font.FontLarge,
 		sdl.Color{R: 255, G: 255, B: 255, A: 255},
-		vec3.Vector3{X: float32(o.WinWidth) * 0.35, Y: float32(o.WinHeight) * 0.48, Z: 0},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.35, Y: float32(o.WinHeight) * 0.36, Z: 0},
 		o.TextFont,
 		renderer)
 
@@ -155,7 +170,7 @@ func NewOptionsScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetra
 	o.SoundVolumeValueText = font.NewTTFString(strconv.Itoa(o.SoundVolume)+" %",
 		font.FontMedium,
 		sdl.Color{R: 255, G: 255, B: 255, A: 255},
-		vec3.Vector3{X: float32(o.WinWidth) * 0.14, Y: float32(o.WinHeight) * 0.50, Z: 0},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.14, Y: float32(o.WinHeight) * 0.38, Z: 0},
 		o.TextFont,
 		renderer)
 
@@ -165,7 +180,7 @@ func NewOptionsScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetra
 		sdl.Color{R: 128, G: 128, B: 128, A: 192},
 		sdl.Color{R: 128, G: 128, B: 192, A: 192},
 		sdl.Color{R: 0, G: 0, B: 255, A: 192},
-		vec3.Vector3{X: float32(o.WinWidth) * 0.25, Y: float32(o.WinHeight) * 0.48, Z: 0},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.25, Y: float32(o.WinHeight) * 0.36, Z: 0},
 		0.1,
 		100,
 		64,
@@ -180,7 +195,7 @@ func NewOptionsScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetra
 		sdl.Color{R: 128, G: 128, B: 128, A: 192},
 		sdl.Color{R: 128, G: 128, B: 192, A: 192},
 		sdl.Color{R: 0, G: 0, B: 255, A: 192},
-		vec3.Vector3{X: float32(o.WinWidth) * 0.05, Y: float32(o.WinHeight) * 0.48, Z: 0},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.05, Y: float32(o.WinHeight) * 0.36, Z: 0},
 		0.1,
 		100,
 		64,
@@ -196,7 +211,7 @@ func NewOptionsScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetra
 	o.MusicVolumeText = font.NewTTFString("Music Volume",
 		font.FontLarge,
 		sdl.Color{R: 255, G: 255, B: 255, A: 255},
-		vec3.Vector3{X: float32(o.WinWidth) * 0.35, Y: float32(o.WinHeight) * 0.65, Z: 0},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.35, Y: float32(o.WinHeight) * 0.47, Z: 0},
 		o.TextFont,
 		renderer)
 
@@ -204,7 +219,7 @@ func NewOptionsScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetra
 	o.MusicVolumeValueText = font.NewTTFString(strconv.Itoa(o.MusicVolume)+" %",
 		font.FontMedium,
 		sdl.Color{R: 255, G: 255, B: 255, A: 255},
-		vec3.Vector3{X: float32(o.WinWidth) * 0.14, Y: float32(o.WinHeight) * 0.67, Z: 0},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.14, Y: float32(o.WinHeight) * 0.49, Z: 0},
 		o.TextFont,
 		renderer)
 
@@ -214,7 +229,7 @@ func NewOptionsScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetra
 		sdl.Color{R: 128, G: 128, B: 128, A: 192},
 		sdl.Color{R: 128, G: 128, B: 192, A: 192},
 		sdl.Color{R: 0, G: 0, B: 255, A: 192},
-		vec3.Vector3{X: float32(o.WinWidth) * 0.25, Y: float32(o.WinHeight) * 0.65, Z: 0},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.25, Y: float32(o.WinHeight) * 0.47, Z: 0},
 		0.1,
 		100,
 		64,
@@ -229,7 +244,103 @@ func NewOptionsScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetra
 		sdl.Color{R: 128, G: 128, B: 128, A: 192},
 		sdl.Color{R: 128, G: 128, B: 192, A: 192},
 		sdl.Color{R: 0, G: 0, B: 255, A: 192},
-		vec3.Vector3{X: float32(o.WinWidth) * 0.05, Y: float32(o.WinHeight) * 0.65, Z: 0},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.05, Y: float32(o.WinHeight) * 0.47, Z: 0},
+		0.1,
+		100,
+		64,
+		64,
+		1,
+		1,
+		renderer)
+
+	o.PreviousPreviewLength = o.GameBoard.PreviewLength
+
+	// Set the next pieces text
+	o.PreviewText = font.NewTTFString("Next Pieces",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.35, Y: float32(o.WinHeight) * 0.58, Z: 0},
+		o.TextFont,
+		renderer)
+
+	// Set the next pieces value text
+	o.PreviewValueText = font.NewTTFString(strconv.Itoa(o.GameBoard.PreviewLength),
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.165, Y: float32(o.WinHeight) * 0.60, Z: 0},
+		o.TextFont,
+		renderer)
+
+	o.PreviewUpButton = guicontrols.NewSpriteButton(o.WinWidth,
+		o.WinHeight,
+		"assets/arrowRight.png",
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.25, Y: float32(o.WinHeight) * 0.58, Z: 0},
+		0.1,
+		100,
+		64,
+		64,
+		1,
+		1,
+		renderer)
+
+	o.PreviewDownButton = guicontrols.NewSpriteButton(o.WinWidth,
+		o.WinHeight,
+		"assets/arrowLeft.png",
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.05, Y: float32(o.WinHeight) * 0.58, Z: 0},
+		0.1,
+		100,
+		64,
+		64,
+		1,
+		1,
+		renderer)
+
+	o.PreviousPairMode = o.GameBoard.PairMode
+
+	// Set the falling pairs text
+	o.PairsText = font.NewTTFString("Falling Pairs",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.35, Y: float32(o.WinHeight) * 0.69, Z: 0},
+		o.TextFont,
+		renderer)
+
+	// Set the falling pairs value text
+	o.PairsValueText = font.NewTTFString(onOff(o.GameBoard.PairMode),
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.15, Y: float32(o.WinHeight) * 0.71, Z: 0},
+		o.TextFont,
+		renderer)
+
+	o.PairsUpButton = guicontrols.NewSpriteButton(o.WinWidth,
+		o.WinHeight,
+		"assets/arrowRight.png",
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.25, Y: float32(o.WinHeight) * 0.69, Z: 0},
+		0.1,
+		100,
+		64,
+		64,
+		1,
+		1,
+		renderer)
+
+	o.PairsDownButton = guicontrols.NewSpriteButton(o.WinWidth,
+		o.WinHeight,
+		"assets/arrowLeft.png",
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: float32(o.WinWidth) * 0.05, Y: float32(o.WinHeight) * 0.69, Z: 0},
 		0.1,
 		100,
 		64,
@@ -246,7 +357,7 @@ func NewOptionsScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetra
 		sdl.Color{R: 128, G: 128, B: 128, A: 192},
 		sdl.Color{R: 128, G: 128, B: 192, A: 192},
 		sdl.Color{R: 0, G: 0, B: 255, A: 192},
-		vec3.Vector3{X: 0, Y: float32(o.WinHeight) * 0.82, Z: 0},
+		vec3.Vector3{X: 0, Y: float32(o.WinHeight) * 0.83, Z: 0},
 		0.1,
 		100,
 		o.TextFont,
@@ -259,12 +370,17 @@ func NewOptionsScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetra
 // Update updates all the objects on the title screen
 func (o *OptionsScreen) Update(time float64) {
 
-	// Return to the title screen if back button is clicked
+	// Return to the screen the options were opened from if back button is clicked
 	if o.BackButton.WasLeftClicked == true {
-		o.MusicPlayer.FutureTune = 0
 		o.MusicPlayer.PastTune = o.MusicPlayer.CurrentTune
 		o.CurrentGameState.TransitioningUp = true
-		o.CurrentGameState.ToState = gamestate.TitleScreen
+		if o.CurrentGameState.FromState == gamestate.Paused {
+			o.MusicPlayer.FutureTune = o.MusicPlayer.CurrentTune
+			o.CurrentGameState.ToState = gamestate.Paused
+		} else {
+			o.MusicPlayer.FutureTune = 0
+			o.CurrentGameState.ToState = gamestate.TitleScreen
+		}
 	}
 
 	// Set the appropriate tune
@@ -320,6 +436,26 @@ func (o *OptionsScreen) Update(time float64) {
 		o.MusicPlayer.SetVolume(o.MusicVolume)
 	}
 
+	// Set the number of upcoming blocks shown next to the gameboard when appropriate button is clicked
+	if o.PreviewUpButton.WasLeftClicked == true {
+		o.GameBoard.PreviewLength++
+		if o.GameBoard.PreviewLength > boardmodel.MaxQueueLength {
+			o.GameBoard.PreviewLength = boardmodel.MaxQueueLength
+		}
+	}
+
+	if o.PreviewDownButton.WasLeftClicked == true {
+		o.GameBoard.PreviewLength--
+		if o.GameBoard.PreviewLength < 1 {
+			o.GameBoard.PreviewLength = 1
+		}
+	}
+
+	// Switch between single blocks and falling pairs when appropriate button is clicked - the change takes effect from the next game
+	if o.PairsUpButton.WasLeftClicked == true || o.PairsDownButton.WasLeftClicked == true {
+		o.GameBoard.PairMode = !o.GameBoard.PairMode
+	}
+
 	// Update the buttons
 	o.BackButton.Update(o.MouseState, time)
 	o.TuneUpButton.Update(o.MouseState, time)
@@ -328,6 +464,10 @@ func (o *OptionsScreen) Update(time float64) {
 	o.SoundVolumeDownButton.Update(o.MouseState, time)
 	o.MusicVolumeUpButton.Update(o.MouseState, time)
 	o.MusicVolumeDownButton.Update(o.MouseState, time)
+	o.PreviewUpButton.Update(o.MouseState, time)
+	o.PreviewDownButton.Update(o.MouseState, time)
+	o.PairsUpButton.Update(o.MouseState, time)
+	o.PairsDownButton.Update(o.MouseState, time)
 }
 
 // Draw draws all the objects on the title screen
@@ -364,6 +504,16 @@ func (o *OptionsScreen) Draw(renderer *sdl.Renderer) {
 		o.PreviousMusicVolume = o.MusicVolume
 	}
 
+	if o.GameBoard.PreviewLength != o.PreviousPreviewLength {
+		o.PreviewValueText.ChangeStringTexture(strconv.Itoa(o.GameBoard.PreviewLength), font.FontMedium, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		o.PreviousPreviewLength = o.GameBoard.PreviewLength
+	}
+
+	if o.GameBoard.PairMode != o.PreviousPairMode {
+		o.PairsValueText.ChangeStringTexture(onOff(o.GameBoard.PairMode), font.FontMedium, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		o.PreviousPairMode = o.GameBoard.PairMode
+	}
+
 	// Draw the text
 	o.TitleText.Draw(renderer)
 	o.InGameTuneText.Draw(renderer)
@@ -372,6 +522,10 @@ func (o *OptionsScreen) Draw(renderer *sdl.Renderer) {
 	o.SoundVolumeValueText.Draw(renderer)
 	o.MusicVolumeText.Draw(renderer)
 	o.MusicVolumeValueText.Draw(renderer)
+	o.PreviewText.Draw(renderer)
+	o.PreviewValueText.Draw(renderer)
+	o.PairsText.Draw(renderer)
+	o.PairsValueText.Draw(renderer)
 
 	// Draw the buttons
 	o.BackButton.Draw(renderer)
@@ -381,4 +535,16 @@ func (o *OptionsScreen) Draw(renderer *sdl.Renderer) {
 	o.SoundVolumeDownButton.Draw(renderer)
 	o.MusicVolumeUpButton.Draw(renderer)
 	o.MusicVolumeDownButton.Draw(renderer)
+	o.PreviewUpButton.Draw(renderer)
+	o.PreviewDownButton.Draw(renderer)
+	o.PairsUpButton.Draw(renderer)
+	o.PairsDownButton.Draw(renderer)
+}
+
+// onOff returns the text shown for a setting that can be switched on and off
+func onOff(on bool) string {
+	if on == true {
+		return "On"
+	}
+	return "Off"
 }
@@ -0,0 +1,205 @@
+package pausescreen
+
+import (
+	"golang-games/PuzzleBlock/font"
+	"golang-games/PuzzleBlock/gameboard"
+	"golang-games/PuzzleBlock/gamestate"
+	"golang-games/PuzzleBlock/gamestatetransition"
+	"golang-games/PuzzleBlock/guicontrols"
+	"golang-games/PuzzleBlock/musicplayer"
+	"golang-games/PuzzleBlock/soundplayer"
+	"golang-games/PuzzleBlock/texturedrawing"
+	"golang-games/PuzzleBlock/vec3"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// PauseScreen is a struct that contains all the sprite information for the menu drawn over a paused game
+type PauseScreen struct {
+	CurrentGameState *gamestatetransition.GameStateTransition
+	MouseState       *guicontrols.MouseState
+	MusicPlayer      *musicplayer.MusicPlayer
+	SoundPlayer      *soundplayer.SoundPlayer
+	GameBoard        *gameboard.GameBoard
+	WinWidth         int
+	WinHeight        int
+	Overlay          *texturedrawing.SinglePixelTexture
+	TextFont         *font.TTFFont
+	TitleText        *font.TTFString
+	ResumeButton     *guicontrols.TextButton
+	RestartButton    *guicontrols.TextButton
+	OptionsButton    *guicontrols.TextButton
+	QuitButton       *guicontrols.TextButton
+}
+
+// NewPauseScreen is a pause screen constructor
+func NewPauseScreen(winWidth, winHeight int, gamestate *gamestatetransition.GameStateTransition, mousestate *guicontrols.MouseState, gameboard *gameboard.GameBoard, musicplayer *musicplayer.MusicPlayer, soundplayer *soundplayer.SoundPlayer, renderer *sdl.Renderer) *PauseScreen {
+
+	p := &PauseScreen{}
+
+	p.CurrentGameState = gamestate
+
+	p.MouseState = mousestate
+
+	p.MusicPlayer = musicplayer
+
+	p.SoundPlayer = soundplayer
+
+	p.GameBoard = gameboard
+
+	p.WinWidth = winWidth
+	p.WinHeight = winHeight
+
+	// Set the translucent overlay that darkens the game underneath the menu
+	p.Overlay = texturedrawing.NewSinglePixelTexture(sdl.Color{R: 0, G: 0, B: 0, A: 160}, sdl.Rect{X: 0, Y: 0, W: int32(winWidth), H: int32(winHeight)}, renderer)
+
+	// Set the font for the text
+	p.TextFont = font.NewTTFFont("assets/FifteenTwenty-Bold.otf", winWidth, winHeight)
+
+	// Set the title text
+	p.TitleText = font.NewTTFString("Paused",
+		font.FontTitle,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: 0, Y: float32(winHeight) * 0.05, Z: 0},
+		p.TextFont,
+		renderer)
+	p.TitleText.SetCenterX()
+
+	p.ResumeButton = guicontrols.NewTextButton(p.WinWidth,
+		p.WinHeight,
+		"   Resume   ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(p.WinHeight) * 0.37, Z: 0},
+		0.1,
+		100,
+		p.TextFont,
+		renderer)
+	p.ResumeButton.SetCenterX()
+
+	p.RestartButton = guicontrols.NewTextButton(p.WinWidth,
+		p.WinHeight,
+		"  Restart  ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(p.WinHeight) * 0.52, Z: 0},
+		0.1,
+		100,
+		p.TextFont,
+		renderer)
+	p.RestartButton.SetCenterX()
+
+	p.OptionsButton = guicontrols.NewTextButton(p.WinWidth,
+		p.WinHeight,
+		"  Options  ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(p.WinHeight) * 0.67, Z: 0},
+		0.1,
+		100,
+		p.TextFont,
+		renderer)
+	p.OptionsButton.SetCenterX()
+
+	p.QuitButton = guicontrols.NewTextButton(p.WinWidth,
+		p.WinHeight,
+		" Quit to Title ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(p.WinHeight) * 0.82, Z: 0},
+		0.1,
+		100,
+		p.TextFont,
+		renderer)
+	p.QuitButton.SetCenterX()
+
+	return p
+}
+
+// Pause freezes the game and brings up the pause menu - only a game that is being played and is not in the middle of a transition can be paused
+func (p *PauseScreen) Pause() {
+	if p.CurrentGameState.CurrentGameState == gamestate.MainGame &&
+		p.CurrentGameState.TransitioningUp == false && p.CurrentGameState.TransitioningDown == false {
+		p.CurrentGameState.CurrentGameState = gamestate.Paused
+
+		// Forget clicks from the last time the menu was up
+		p.ResumeButton.WasLeftClicked = false
+		p.RestartButton.WasLeftClicked = false
+		p.OptionsButton.WasLeftClicked = false
+		p.QuitButton.WasLeftClicked = false
+	}
+}
+
+// Resume goes straight back to the paused game
+func (p *PauseScreen) Resume() {
+	if p.CurrentGameState.CurrentGameState == gamestate.Paused && p.CurrentGameState.TransitioningUp == false {
+		p.CurrentGameState.CurrentGameState = gamestate.MainGame
+	}
+}
+
+// Update updates all the objects on the pause screen
+func (p *PauseScreen) Update(time float64) {
+
+	// Go back to the game if the resume button is clicked
+	if p.ResumeButton.WasLeftClicked == true {
+		p.Resume()
+	}
+
+	// Start the game or puzzle over if the restart button is clicked
+	if p.RestartButton.WasLeftClicked == true && p.CurrentGameState.TransitioningUp == false {
+		p.GameBoard.Restart()
+		p.MusicPlayer.FutureTune = p.MusicPlayer.CurrentTune
+		p.CurrentGameState.TransitioningUp = true
+		p.CurrentGameState.ToState = gamestate.MainGame
+	}
+
+	// Change to Options screen if the options button is clicked - the options screen comes back here when it is done
+	if p.OptionsButton.WasLeftClicked == true {
+		p.MusicPlayer.FutureTune = p.MusicPlayer.CurrentTune
+		p.CurrentGameState.TransitioningUp = true
+		p.CurrentGameState.ToState = gamestate.OptionsScreen
+	}
+
+	// Abandon the game and return to the title screen if the quit button is clicked
+	if p.QuitButton.WasLeftClicked == true && p.CurrentGameState.TransitioningUp == false {
+		p.GameBoard.NewGame()
+		p.MusicPlayer.FutureTune = 0
+		p.CurrentGameState.TransitioningUp = true
+		p.CurrentGameState.ToState = gamestate.TitleScreen
+	}
+
+	// Update the buttons
+	p.ResumeButton.Update(p.MouseState, time)
+	p.RestartButton.Update(p.MouseState, time)
+	p.OptionsButton.Update(p.MouseState, time)
+	p.QuitButton.Update(p.MouseState, time)
+}
+
+// Draw draws the paused game with the pause menu over it
+func (p *PauseScreen) Draw(renderer *sdl.Renderer) {
+
+	// Draw the game underneath the overlay
+	p.GameBoard.Draw(renderer)
+	p.Overlay.Draw(renderer)
+
+	// Draw the text
+	p.TitleText.Draw(renderer)
+
+	// Draw the buttons
+	p.ResumeButton.Draw(renderer)
+	p.RestartButton.Draw(renderer)
+	p.OptionsButton.Draw(renderer)
+	p.QuitButton.Draw(renderer)
+}
@@ -0,0 +1,245 @@
+package puzzlescreen
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"golang-games/PuzzleBlock/font"
+	"golang-games/PuzzleBlock/gameboard"
+	"golang-games/PuzzleBlock/gamestate"
+	"golang-games/PuzzleBlock/gamestatetransition"
+	"golang-games/PuzzleBlock/guicontrols"
+	"golang-games/PuzzleBlock/musicplayer"
+	"golang-games/PuzzleBlock/soundplayer"
+	"golang-games/PuzzleBlock/sprite"
+	"golang-games/PuzzleBlock/vec3"
+	"strconv"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// PuzzleScreen is a struct that contains all the sprite information for the screen shown when a puzzle ends
+type PuzzleScreen struct {
+	CurrentGameState *gamestatetransition.GameStateTransition
+	MouseState       *guicontrols.MouseState
+	MusicPlayer      *musicplayer.MusicPlayer
+	SoundPlayer      *soundplayer.SoundPlayer
+	GameBoard        *gameboard.GameBoard
+	WinWidth         int
+	WinHeight        int
+	Background       *sprite.Sprite
+	TextFont         *font.TTFFont
+	CurrentPuzzle    *boardmodel.Puzzle
+	PrevPuzzle       *boardmodel.Puzzle
+	CurrentSolved    bool
+	PrevSolved       bool
+	TitleText        *font.TTFString
+	NameText         *font.TTFString
+	GoalText         *font.TTFString
+	ProgressText     *font.TTFString
+	NextButton       *guicontrols.TextButton
+	RetryButton      *guicontrols.TextButton
+	MainMenuButton   *guicontrols.TextButton
+}
+
+// NewPuzzleScreen is a puzzle screen constructor
+func NewPuzzleScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetransition.GameStateTransition, mousestate *guicontrols.MouseState, gameboard *gameboard.GameBoard, musicplayer *musicplayer.MusicPlayer, soundplayer *soundplayer.SoundPlayer, renderer *sdl.Renderer) *PuzzleScreen {
+
+	p := &PuzzleScreen{}
+
+	p.CurrentGameState = gamestate
+
+	p.MouseState = mousestate
+
+	p.MusicPlayer = musicplayer
+
+	p.SoundPlayer = soundplayer
+
+	p.GameBoard = gameboard
+
+	p.WinWidth = winWidth
+	p.WinHeight = winHeight
+
+	// Set the background image
+	p.Background = sprite.NewSprite(
+		"assets/background.png",
+		vec3.Vector3{X: 0, Y: 0, Z: 0},
+		vec3.Vector3{X: 0, Y: 0, Z: 0},
+		1280,
+		720,
+		float64(winWidth)/1280,
+		float64(winHeight)/720,
+		1,
+		1,
+		0,
+		0,
+		true,
+		0,
+		false,
+		renderer)
+
+	// Set the font for the text
+	p.TextFont = font.NewTTFFont("assets/FifteenTwenty-Bold.otf", winWidth, winHeight)
+
+	// The text is filled in by Draw once a puzzle has ended
+	p.CurrentPuzzle = nil
+	p.PrevPuzzle = nil
+
+	// Set the title text
+	p.TitleText = font.NewTTFString(" ",
+		font.FontTitle,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: 0, Y: float32(winHeight) * 0.05, Z: 0},
+		p.TextFont,
+		renderer)
+
+	// Set the puzzle text
+	p.NameText = font.NewTTFString(" ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: 0, Y: float32(p.WinHeight) * 0.30, Z: 0},
+		p.TextFont,
+		renderer)
+
+	p.GoalText = font.NewTTFString(" ",
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 0, A: 255},
+		vec3.Vector3{X: 0, Y: float32(p.WinHeight) * 0.40, Z: 0},
+		p.TextFont,
+		renderer)
+
+	p.ProgressText = font.NewTTFString(" ",
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: 0, Y: float32(p.WinHeight) * 0.48, Z: 0},
+		p.TextFont,
+		renderer)
+
+	p.NextButton = guicontrols.NewTextButton(p.WinWidth,
+		p.WinHeight,
+		" Next Puzzle ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(p.WinHeight) * 0.66, Z: 0},
+		0.1,
+		100,
+		p.TextFont,
+		renderer)
+	p.NextButton.SetCenterX()
+
+	p.RetryButton = guicontrols.NewTextButton(p.WinWidth,
+		p.WinHeight,
+		" Try Again ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(p.WinHeight) * 0.66, Z: 0},
+		0.1,
+		100,
+		p.TextFont,
+		renderer)
+	p.RetryButton.SetCenterX()
+
+	p.MainMenuButton = guicontrols.NewTextButton(p.WinWidth,
+		p.WinHeight,
+		" Main Menu ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(p.WinHeight) * 0.80, Z: 0},
+		0.1,
+		100,
+		p.TextFont,
+		renderer)
+	p.MainMenuButton.SetCenterX()
+
+	return p
+}
+
+// Update updates all the objects on the puzzle screen
+func (p *PuzzleScreen) Update(time float64) {
+
+	// Pick up the puzzle that has just ended - the board is left alone once a button has started the next game
+	if p.CurrentGameState.TransitioningUp == false {
+		p.CurrentPuzzle = p.GameBoard.Board.Puzzle
+		p.CurrentSolved = p.GameBoard.Board.PuzzleSolved
+	}
+
+	// Move on to the next puzzle if the next button is clicked - the last puzzle leads back to the first
+	if p.CurrentSolved == true && p.NextButton.WasLeftClicked == true && p.CurrentGameState.TransitioningUp == false {
+		p.GameBoard.StartPuzzle((p.GameBoard.PuzzleIndex + 1) % len(p.GameBoard.Puzzles))
+		p.MusicPlayer.FutureTune = p.MusicPlayer.PastTune
+		p.CurrentGameState.TransitioningUp = true
+		p.CurrentGameState.ToState = gamestate.MainGame
+	}
+
+	// Start the same puzzle over if the retry button is clicked
+	if p.CurrentSolved == false && p.RetryButton.WasLeftClicked == true && p.CurrentGameState.TransitioningUp == false {
+		p.GameBoard.StartPuzzle(p.GameBoard.PuzzleIndex)
+		p.MusicPlayer.FutureTune = p.MusicPlayer.PastTune
+		p.CurrentGameState.TransitioningUp = true
+		p.CurrentGameState.ToState = gamestate.MainGame
+	}
+
+	// Return to the title screen if the main menu button is clicked
+	if p.MainMenuButton.WasLeftClicked == true && p.CurrentGameState.TransitioningUp == false {
+		p.GameBoard.NewGame()
+		p.MusicPlayer.FutureTune = 0
+		p.CurrentGameState.TransitioningUp = true
+		p.CurrentGameState.ToState = gamestate.TitleScreen
+	}
+
+	// Update the buttons
+	if p.CurrentSolved == true {
+		p.NextButton.Update(p.MouseState, time)
+	} else {
+		p.RetryButton.Update(p.MouseState, time)
+	}
+	p.MainMenuButton.Update(p.MouseState, time)
+}
+
+// Draw draws all the objects on the puzzle screen
+func (p *PuzzleScreen) Draw(renderer *sdl.Renderer) {
+
+	// Draw the background
+	p.Background.Draw(renderer)
+
+	// Change the display text when another puzzle has ended
+	if p.CurrentPuzzle != nil && (p.CurrentPuzzle != p.PrevPuzzle || p.CurrentSolved != p.PrevSolved) {
+		title := "Failed"
+		if p.CurrentSolved == true && p.GameBoard.PuzzleIndex == len(p.GameBoard.Puzzles)-1 {
+			title = "All Solved!"
+		} else if p.CurrentSolved == true {
+			title = "Solved!"
+		}
+		p.TitleText.ChangeStringTexture(title, font.FontTitle, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		p.TitleText.SetCenterX()
+		p.NameText.ChangeStringTexture(p.CurrentPuzzle.Name, font.FontLarge, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		p.NameText.SetCenterX()
+		p.GoalText.ChangeStringTexture(p.CurrentPuzzle.Goal.String(), font.FontMedium, sdl.Color{R: 255, G: 255, B: 0, A: 255}, renderer)
+		p.GoalText.SetCenterX()
+		p.ProgressText.ChangeStringTexture("Puzzle "+strconv.Itoa(p.GameBoard.PuzzleIndex+1)+" of "+strconv.Itoa(len(p.GameBoard.Puzzles)), font.FontMedium, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		p.ProgressText.SetCenterX()
+		p.PrevPuzzle = p.CurrentPuzzle
+		p.PrevSolved = p.CurrentSolved
+	}
+
+	// Draw the text
+	p.TitleText.Draw(renderer)
+	p.NameText.Draw(renderer)
+	p.GoalText.Draw(renderer)
+	p.ProgressText.Draw(renderer)
+
+	// Draw the buttons - a solved puzzle moves on, a failed one can be tried again
+	if p.CurrentSolved == true {
+		p.NextButton.Draw(renderer)
+	} else {
+		p.RetryButton.Draw(renderer)
+	}
+	p.MainMenuButton.Draw(renderer)
+}
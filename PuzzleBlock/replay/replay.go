@@ -0,0 +1,170 @@
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"golang-games/PuzzleBlock/boardmodel"
+	"golang-games/PuzzleBlock/userdata"
+	"io/ioutil"
+	"strconv"
+	"time"
+)
+
+// Version is the version of the replay file format written by this build of the game - it goes up whenever the same seed and inputs would play out differently
+const Version = 10
+
+// Replay holds everything needed to play a recorded game back exactly
+type Replay struct {
+	Version int                `json:"version"`
+	Seed    int64              `json:"seed"`
+	Width   int                `json:"width"`
+	Height  int                `json:"height"`
+	Pairs   bool               `json:"pairs"`
+	Players int                `json:"players"`
+	Rules   boardmodel.Rules   `json:"rules"`
+	Levels  []boardmodel.Level `json:"levels"`
+	Start   int                `json:"start_level"`
+	Puzzle  *boardmodel.Puzzle `json:"puzzle,omitempty"`
+	Mode    boardmodel.Mode    `json:"mode"`
+	Frames  int                `json:"frames"`
+	Score   int                `json:"score"`
+	Inputs  []boardmodel.Input `json:"inputs"`
+}
+
+// NewReplay creates a replay from the inputs a board has recorded since its game started
+func NewReplay(b *boardmodel.Board) *Replay {
+
+	r := &Replay{}
+
+	r.Version = Version
+	r.Seed = b.Seed
+	r.Width = b.Width
+	r.Height = b.Height
+	r.Pairs = b.PairMode
+	r.Players = len(b.Pieces)
+	r.Rules = b.Rules
+	r.Levels = make([]boardmodel.Level, len(b.Levels))
+	copy(r.Levels, b.Levels)
+	r.Start = b.StartLevel
+	r.Puzzle = b.Puzzle
+	r.Mode = b.Mode
+	r.Frames = b.Frame
+	r.Score = b.ScoreValue
+	r.Inputs = make([]boardmodel.Input, len(b.Inputs))
+	copy(r.Inputs, b.Inputs)
+
+	return r
+}
+
+// Load reads a replay file
+func Load(path string) (*Replay, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Replay{}
+	err = json.Unmarshal(data, r)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Version != Version {
+		return nil, errors.New("replay: " + path + " has version " + strconv.Itoa(r.Version) + ", expected " + strconv.Itoa(Version))
+	}
+
+	err = r.Rules.Validate()
+	if err != nil {
+		return nil, errors.New("replay: " + path + ": " + err.Error())
+	}
+
+	if r.Mode.Name == "" || r.Mode.TimeLimit < 0 || r.Mode.BlockGoal < 0 {
+		return nil, errors.New("replay: " + path + " has no mode that can be played")
+	}
+
+	if r.Players < 1 || r.Players > boardmodel.MaxPlayers {
+		return nil, errors.New("replay: " + path + " has " + strconv.Itoa(r.Players) + " players, expected 1 to " + strconv.Itoa(boardmodel.MaxPlayers))
+	}
+
+	err = boardmodel.ValidateLevels(r.Levels)
+	if err != nil {
+		return nil, errors.New("replay: " + path + ": " + err.Error())
+	}
+
+	if r.Start < 1 || r.Start > len(r.Levels) {
+		return nil, errors.New("replay: " + path + " starts on level " + strconv.Itoa(r.Start) + ", expected 1 to " + strconv.Itoa(len(r.Levels)))
+	}
+
+	if r.Puzzle != nil {
+		err = r.Puzzle.Validate(r.Width, r.Height)
+		if err != nil {
+			return nil, errors.New("replay: " + path + ": " + err.Error())
+		}
+	}
+
+	return r, nil
+}
+
+// Save writes the replay to a file
+func (r *Replay) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// SaveToUserData writes the replay to a time stamped file in the replays folder of the user's config directory and returns where it went
+func (r *Replay) SaveToUserData() (string, error) {
+	path, err := userdata.Path("replays/" + time.Now().Format("20060102-150405") + ".json")
+	if err != nil {
+		return "", err
+	}
+
+	return path, r.Save(path)
+}
+
+// Play restarts the board from the replay's seed and feeds the recorded inputs back into it
+func (r *Replay) Play(b *boardmodel.Board) error {
+	if b.Width != r.Width || b.Height != r.Height {
+		return errors.New("replay: recorded on a " + strconv.Itoa(r.Width) + "x" + strconv.Itoa(r.Height) + " board, cannot play on a " + strconv.Itoa(b.Width) + "x" + strconv.Itoa(b.Height) + " board")
+	}
+
+	b.PairMode = r.Pairs
+	b.Rules = r.Rules
+	b.Levels = r.Levels
+	b.StartLevel = r.Start
+	b.Mode = r.Mode
+	b.SetPlayers(r.Players)
+	b.StartPlayback(r.Seed, r.Inputs)
+
+	if r.Puzzle != nil {
+		return b.SetPuzzle(r.Puzzle)
+	}
+
+	return nil
+}
+
+// Simulate plays the replay on a new board without drawing anything and returns the board as it was on the last recorded frame
+func (r *Replay) Simulate() *boardmodel.Board {
+	b := boardmodel.NewBoard(r.Width, r.Height, r.Seed)
+	b.PairMode = r.Pairs
+	b.Rules = r.Rules
+	b.Levels = r.Levels
+	b.StartLevel = r.Start
+	b.Mode = r.Mode
+	b.SetPlayers(r.Players)
+	b.StartPlayback(r.Seed, r.Inputs)
+
+	// The puzzle was checked when the replay was loaded
+	if r.Puzzle != nil {
+		b.SetPuzzle(r.Puzzle)
+	}
+
+	for b.Frame < r.Frames && b.GameOver == false {
+		b.Step()
+	}
+
+	return b
+}
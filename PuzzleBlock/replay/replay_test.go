@@ -0,0 +1,95 @@
+package replay
+
+import (
+	"golang-games/PuzzleBlock/boardmodel"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// steer presses the key that moves the active block of 'b' over a column it matches the top of, or over the lowest column if none match, and pushes it down once it is there
+func steer(b *boardmodel.Board) {
+	a := b.Pieces[0].Pos
+	if a.X == -1 || a.Y == -1 {
+		return
+	}
+
+	target, lowest, lowestTop := -1, -1, -1
+	for i := 0; i < b.Width; i++ {
+		top := a.Y + 1
+		for top < b.Height && b.BlockStates[top][i] == boardmodel.Empty {
+			top++
+		}
+		if target == -1 && top < b.Height && b.BlockColors[top][i] == b.BlockColors[a.Y][a.X] {
+			target = i
+		}
+		if top > lowestTop {
+			lowest, lowestTop = i, top
+		}
+	}
+	if target == -1 {
+		target = lowest
+	}
+
+	switch {
+	case target < a.X:
+		b.MoveActiveBlock("left")
+	case target > a.X:
+		b.MoveActiveBlock("right")
+	default:
+		b.MoveActiveBlock("down")
+	}
+}
+
+// record plays a game of 'frames' frames from 'seed', steering the active block every few frames, and returns the board that recorded it
+func record(seed int64, frames int) *boardmodel.Board {
+	b := boardmodel.NewBoard(5, 10, seed)
+	b.StartRecording(seed)
+	for f := 0; f < frames && b.GameOver == false; f++ {
+		if f%7 == 0 {
+			steer(b)
+		}
+		b.Step()
+	}
+	return b
+}
+
+func TestReplayRoundTrip(t *testing.T) {
+	b := record(1, 20000)
+	if b.ScoreValue == 0 {
+		t.Fatal("the game scored no points, so it tests nothing")
+	}
+
+	path := filepath.Join(t.TempDir(), "replay.json")
+	err := NewReplay(b).Save(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := r.Simulate()
+	if reflect.DeepEqual(s.BlockStates, b.BlockStates) == false || reflect.DeepEqual(s.BlockColors, b.BlockColors) == false {
+		t.Errorf("replay plays back to a different board")
+	}
+	if s.ScoreValue != b.ScoreValue || s.Frame != b.Frame || s.GameOver != b.GameOver {
+		t.Errorf("replay plays back to score %d on frame %d (game over %v), expected score %d on frame %d (game over %v)", s.ScoreValue, s.Frame, s.GameOver, b.ScoreValue, b.Frame, b.GameOver)
+	}
+}
+
+func TestLoadRejectsOtherVersions(t *testing.T) {
+	r := NewReplay(record(1, 100))
+	r.Version = Version + 1
+
+	path := filepath.Join(t.TempDir(), "replay.json")
+	err := r.Save(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = Load(path)
+	if err == nil {
+		t.Errorf("loaded a replay of version %d, expected an error", r.Version)
+	}
+}
@@ -0,0 +1,344 @@
+package setupscreen
+
+import (
+	"golang-games/PuzzleBlock/font"
+	"golang-games/PuzzleBlock/gameboard"
+	"golang-games/PuzzleBlock/gamestate"
+	"golang-games/PuzzleBlock/gamestatetransition"
+	"golang-games/PuzzleBlock/guicontrols"
+	"golang-games/PuzzleBlock/musicplayer"
+	"golang-games/PuzzleBlock/soundplayer"
+	"golang-games/PuzzleBlock/sprite"
+	"golang-games/PuzzleBlock/vec3"
+	"strconv"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Setting is a single line of the setup screen - its name, its value and the buttons that change it
+type Setting struct {
+	Text       *font.TTFString
+	ValueText  *font.TTFString
+	UpButton   *guicontrols.SpriteButton
+	DownButton *guicontrols.SpriteButton
+	PrevValue  string
+}
+
+// SetupScreen is a struct that contains all the sprite information for the screen a single player game is set up on before it starts
+type SetupScreen struct {
+	CurrentGameState *gamestatetransition.GameStateTransition
+	MouseState       *guicontrols.MouseState
+	MusicPlayer      *musicplayer.MusicPlayer
+	SoundPlayer      *soundplayer.SoundPlayer
+	GameBoard        *gameboard.GameBoard
+	Setup            gameboard.Setup
+	WinWidth         int
+	WinHeight        int
+	Background       *sprite.Sprite
+	TextFont         *font.TTFFont
+	TitleText        *font.TTFString
+	WidthSetting     *Setting
+	HeightSetting    *Setting
+	LevelSetting     *Setting
+	ColorsSetting    *Setting
+	GraySetting      *Setting
+	MultiSetting     *Setting
+	SpecialSetting   *Setting
+	PlayButton       *guicontrols.TextButton
+	BackButton       *guicontrols.TextButton
+}
+
+// NewSetupScreen is a setup screen constructor - the choices made on it are played with on 'gameboard'
+func NewSetupScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetransition.GameStateTransition, mousestate *guicontrols.MouseState, gameboard *gameboard.GameBoard, musicplayer *musicplayer.MusicPlayer, soundplayer *soundplayer.SoundPlayer, renderer *sdl.Renderer) *SetupScreen {
+
+	s := &SetupScreen{}
+
+	s.CurrentGameState = gamestate
+
+	s.MouseState = mousestate
+
+	s.MusicPlayer = musicplayer
+
+	s.SoundPlayer = soundplayer
+
+	s.GameBoard = gameboard
+
+	s.Setup = gameboard.Setup
+
+	s.WinWidth = winWidth
+	s.WinHeight = winHeight
+
+	// Set the background image
+	s.Background = sprite.NewSprite(
+		"assets/background.png",
+		vec3.Vector3{X: 0, Y: 0, Z: 0},
+		vec3.Vector3{X: 0, Y: 0, Z: 0},
+		1280,
+		720,
+		float64(winWidth)/1280,
+		float64(winHeight)/720,
+		1,
+		1,
+		0,
+		0,
+		true,
+		0,
+		false,
+		renderer)
+
+	// Set the font for the text
+	s.TextFont = font.NewTTFFont("assets/FifteenTwenty-Bold.otf", winWidth, winHeight)
+
+	// Set the title text
+	s.TitleText = font.NewTTFString("Game Setup",
+		font.FontTitle,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: 0, Y: float32(winHeight) * 0.05, Z: 0},
+		s.TextFont,
+		renderer)
+	s.TitleText.SetCenterX()
+
+	// Set a line for every setting down the screen
+	values := s.values()
+	s.WidthSetting = s.newSetting("Board Width", values[0], 0, renderer)
+	s.HeightSetting = s.newSetting("Board Height", values[1], 1, renderer)
+	s.LevelSetting = s.newSetting("Start Level", values[2], 2, renderer)
+	s.ColorsSetting = s.newSetting("Colors", values[3], 3, renderer)
+	s.GraySetting = s.newSetting("Gray Gems", values[4], 4, renderer)
+	s.MultiSetting = s.newSetting("Multi Gems", values[5], 5, renderer)
+	s.SpecialSetting = s.newSetting("Special Gems", values[6], 6, renderer)
+
+	s.PlayButton = guicontrols.NewTextButton(s.WinWidth,
+		s.WinHeight,
+		"   Play   ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(s.WinHeight) * 0.85, Z: 0},
+		0.1,
+		100,
+		s.TextFont,
+		renderer)
+	s.PlayButton.SetButtonPosition(vec3.Vector3{X: float32(s.WinWidth)*0.3 - float32(s.PlayButton.W/2-s.PlayButton.BorderOffset), Y: s.PlayButton.TextPos.Y, Z: 0})
+
+	s.BackButton = guicontrols.NewTextButton(s.WinWidth,
+		s.WinHeight,
+		"   Back   ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(s.WinHeight) * 0.85, Z: 0},
+		0.1,
+		100,
+		s.TextFont,
+		renderer)
+	s.BackButton.SetButtonPosition(vec3.Vector3{X: float32(s.WinWidth)*0.7 - float32(s.BackButton.W/2-s.BackButton.BorderOffset), Y: s.BackButton.TextPos.Y, Z: 0})
+
+	return s
+}
+
+// newSetting returns the 'row'-th line of settings, named 'name' and showing 'value' - the lines are laid out like those of the options screen
+func (s *SetupScreen) newSetting(name, value string, row int, renderer *sdl.Renderer) *Setting {
+	y := 0.18 + 0.09*float32(row)
+
+	setting := &Setting{}
+
+	setting.Text = font.NewTTFString(name,
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: float32(s.WinWidth) * 0.35, Y: float32(s.WinHeight) * y, Z: 0},
+		s.TextFont,
+		renderer)
+
+	setting.ValueText = font.NewTTFString(value,
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: float32(s.WinWidth) * 0.12, Y: float32(s.WinHeight) * (y + 0.02), Z: 0},
+		s.TextFont,
+		renderer)
+	setting.PrevValue = value
+
+	setting.UpButton = guicontrols.NewSpriteButton(s.WinWidth,
+		s.WinHeight,
+		"assets/arrowRight.png",
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: float32(s.WinWidth) * 0.25, Y: float32(s.WinHeight) * y, Z: 0},
+		0.1,
+		100,
+		64,
+		64,
+		1,
+		1,
+		renderer)
+
+	setting.DownButton = guicontrols.NewSpriteButton(s.WinWidth,
+		s.WinHeight,
+		"assets/arrowLeft.png",
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: float32(s.WinWidth) * 0.05, Y: float32(s.WinHeight) * y, Z: 0},
+		0.1,
+		100,
+		64,
+		64,
+		1,
+		1,
+		renderer)
+
+	return setting
+}
+
+// settings returns every line of settings, top first
+func (s *SetupScreen) settings() []*Setting {
+	return []*Setting{s.WidthSetting, s.HeightSetting, s.LevelSetting, s.ColorsSetting, s.GraySetting, s.MultiSetting, s.SpecialSetting}
+}
+
+// values returns the text shown for the value of every setting, top first
+func (s *SetupScreen) values() []string {
+	colors := strconv.Itoa(s.Setup.Colors)
+	if s.Setup.Colors == 0 {
+		colors = "Levels"
+	}
+
+	return []string{
+		strconv.Itoa(s.Setup.Width),
+		strconv.Itoa(s.Setup.Height),
+		strconv.Itoa(s.Setup.StartLevel),
+		colors,
+		onOff(s.Setup.GrayGems),
+		onOff(s.Setup.MultiGems),
+		onOff(s.Setup.SpecialGems),
+	}
+}
+
+// Start lays the gameboard out for the setup and starts a new game on it
+func (s *SetupScreen) Start() {
+	s.GameBoard.Setup = s.Setup
+	s.GameBoard.Resize(s.Setup.Width, s.Setup.Height)
+	s.GameBoard.NewGame()
+}
+
+// Update updates all the objects on the setup screen
+func (s *SetupScreen) Update(time float64) {
+
+	// Start the game if the play button is clicked
+	if s.PlayButton.WasLeftClicked == true && s.CurrentGameState.TransitioningUp == false {
+		s.Start()
+		s.MusicPlayer.FutureTune = s.MusicPlayer.PastTune
+		s.CurrentGameState.TransitioningUp = true
+		s.CurrentGameState.ToState = gamestate.MainGame
+	}
+
+	// Return to the mode screen if the back button is clicked
+	if s.BackButton.WasLeftClicked == true && s.CurrentGameState.TransitioningUp == false {
+		s.MusicPlayer.FutureTune = 0
+		s.CurrentGameState.TransitioningUp = true
+		s.CurrentGameState.ToState = gamestate.ModeSelect
+	}
+
+	// Set the size of the play area when appropriate button is clicked
+	if s.WidthSetting.UpButton.WasLeftClicked == true && s.Setup.Width < gameboard.MaxBoardWidth {
+		s.Setup.Width++
+	}
+
+	if s.WidthSetting.DownButton.WasLeftClicked == true && s.Setup.Width > gameboard.MinBoardWidth {
+		s.Setup.Width--
+	}
+
+	if s.HeightSetting.UpButton.WasLeftClicked == true && s.Setup.Height < gameboard.MaxBoardHeight {
+		s.Setup.Height++
+	}
+
+	if s.HeightSetting.DownButton.WasLeftClicked == true && s.Setup.Height > gameboard.MinBoardHeight {
+		s.Setup.Height--
+	}
+
+	// Set the level the game starts on when appropriate button is clicked - it can be any level of the level table
+	if s.LevelSetting.UpButton.WasLeftClicked == true && s.Setup.StartLevel < len(s.GameBoard.Levels) {
+		s.Setup.StartLevel++
+	}
+
+	if s.LevelSetting.DownButton.WasLeftClicked == true && s.Setup.StartLevel > 1 {
+		s.Setup.StartLevel--
+	}
+
+	// Set the number of colors dealt out when appropriate button is clicked - below the fewest colors the level table picks them again
+	if s.ColorsSetting.UpButton.WasLeftClicked == true {
+		if s.Setup.Colors == 0 {
+			s.Setup.Colors = gameboard.MinColors
+		} else if s.Setup.Colors < gameboard.MaxColors {
+			s.Setup.Colors++
+		}
+	}
+
+	if s.ColorsSetting.DownButton.WasLeftClicked == true {
+		s.Setup.Colors--
+		if s.Setup.Colors < gameboard.MinColors {
+			s.Setup.Colors = 0
+		}
+	}
+
+	// Switch Gray, Multi and special gems on and off when appropriate button is clicked
+	if s.GraySetting.UpButton.WasLeftClicked == true || s.GraySetting.DownButton.WasLeftClicked == true {
+		s.Setup.GrayGems = !s.Setup.GrayGems
+	}
+
+	if s.MultiSetting.UpButton.WasLeftClicked == true || s.MultiSetting.DownButton.WasLeftClicked == true {
+		s.Setup.MultiGems = !s.Setup.MultiGems
+	}
+
+	if s.SpecialSetting.UpButton.WasLeftClicked == true || s.SpecialSetting.DownButton.WasLeftClicked == true {
+		s.Setup.SpecialGems = !s.Setup.SpecialGems
+	}
+
+	// Update the buttons
+	s.PlayButton.Update(s.MouseState, time)
+	s.BackButton.Update(s.MouseState, time)
+	for _, setting := range s.settings() {
+		setting.UpButton.Update(s.MouseState, time)
+		setting.DownButton.Update(s.MouseState, time)
+	}
+}
+
+// Draw draws all the objects on the setup screen
+func (s *SetupScreen) Draw(renderer *sdl.Renderer) {
+
+	// Draw the background
+	s.Background.Draw(renderer)
+
+	// Draw the title
+	s.TitleText.Draw(renderer)
+
+	// Change the display text of any setting whose value has changed, then draw the settings
+	values := s.values()
+	for k, setting := range s.settings() {
+		if values[k] != setting.PrevValue {
+			setting.ValueText.ChangeStringTexture(values[k], font.FontMedium, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+			setting.PrevValue = values[k]
+		}
+
+		setting.Text.Draw(renderer)
+		setting.ValueText.Draw(renderer)
+		setting.UpButton.Draw(renderer)
+		setting.DownButton.Draw(renderer)
+	}
+
+	// Draw the buttons
+	s.PlayButton.Draw(renderer)
+	s.BackButton.Draw(renderer)
+}
+
+// onOff returns the text shown for a setting that can be switched on and off
+func onOff(on bool) string {
+	if on == true {
+		return "On"
+	}
+	return "Off"
+}
@@ -110,3 +110,8 @@ func (s *Sprite) Draw(renderer *sdl.Renderer) {
 		renderer.Copy(s.Tex, s.Src, s.Dst)
 	}
 }
+
+// Destroy frees the texture of the sprite - the sprite can not be drawn after it
+func (s *Sprite) Destroy() {
+	s.Tex.Destroy()
+}
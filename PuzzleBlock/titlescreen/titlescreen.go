@@ -1,7 +1,9 @@
 package titlescreen
 
 import (
+	"golang-games/PuzzleBlock/demoscreen"
 	"golang-games/PuzzleBlock/font"
+	"golang-games/PuzzleBlock/gameboard"
 	"golang-games/PuzzleBlock/gamestate"
 	"golang-games/PuzzleBlock/gamestatetransition"
 	"golang-games/PuzzleBlock/guicontrols"
@@ -20,6 +22,10 @@ type TitleScreen struct {
 	MouseState       *guicontrols.MouseState
 	MusicPlayer      *musicplayer.MusicPlayer
 	SoundPlayer      *soundplayer.SoundPlayer
+	GameBoard        *gameboard.GameBoard
+	DemoScreen       *demoscreen.DemoScreen
+	IdleTime         float64
+	IdleTimer        float64
 	WinWidth         int
 	WinHeight        int
 	Blocks           []*sprite.Sprite
@@ -27,12 +33,14 @@ type TitleScreen struct {
 	TextFont         *font.TTFFont
 	TitleText        *font.TTFString
 	StartButton      *guicontrols.TextButton
+	PuzzlesButton    *guicontrols.TextButton
 	OptionsButton    *guicontrols.TextButton
+	HighScoresButton *guicontrols.TextButton
 	QuitButton       *guicontrols.TextButton
 }
 
-// NewTitleScreen is a title screen constructor
-func NewTitleScreen(winWidth, winHeight, winDepth, numBlocks int, gamestate *gamestatetransition.GameStateTransition, mousestate *guicontrols.MouseState, musicplayer *musicplayer.MusicPlayer, soundplayer *soundplayer.SoundPlayer, renderer *sdl.Renderer) *TitleScreen {
+// NewTitleScreen is a title screen constructor - 'demoscreen' is shown once the title screen has been left alone for a while
+func NewTitleScreen(winWidth, winHeight, winDepth, numBlocks int, gamestate *gamestatetransition.GameStateTransition, mousestate *guicontrols.MouseState, gameboard *gameboard.GameBoard, demoscreen *demoscreen.DemoScreen, musicplayer *musicplayer.MusicPlayer, soundplayer *soundplayer.SoundPlayer, renderer *sdl.Renderer) *TitleScreen {
 
 	t := &TitleScreen{}
 
@@ -44,6 +52,13 @@ func NewTitleScreen(winWidth, winHeight, winDepth, numBlocks int, gamestate *gam
 
 	t.SoundPlayer = soundplayer
 
+	t.GameBoard = gameboard
+
+	t.DemoScreen = demoscreen
+
+	t.IdleTime = 20000
+	t.IdleTimer = 0
+
 	t.WinWidth = winWidth
 	t.WinHeight = winHeight
 
@@ -68,9 +83,9 @@ func NewTitleScreen(winWidth, winHeight, winDepth, numBlocks int, gamestate *gam
 			blockScale,
 			blockScale,
 			10,
-			7,
+			8,
 			rand.Intn(10),
-			rand.Intn(7),
+			rand.Intn(8),
 			true,
 			100,
 			true,
@@ -116,13 +131,28 @@ func NewTitleScreen(winWidth, winHeight, winDepth, numBlocks int, gamestate *gam
 		sdl.Color{R: 128, G: 128, B: 128, A: 192},
 		sdl.Color{R: 128, G: 128, B: 192, A: 192},
 		sdl.Color{R: 0, G: 0, B: 255, A: 192},
-		vec3.Vector3{X: 0, Y: float32(t.WinHeight) * 0.48, Z: 0},
+		vec3.Vector3{X: 0, Y: float32(t.WinHeight) * 0.34, Z: 0},
 		0.1,
 		100,
 		t.TextFont,
 		renderer)
 	t.StartButton.SetCenterX()
 
+	t.PuzzlesButton = guicontrols.NewTextButton(t.WinWidth,
+		t.WinHeight,
+		"  Puzzles  ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(t.WinHeight) * 0.46, Z: 0},
+		0.1,
+		100,
+		t.TextFont,
+		renderer)
+	t.PuzzlesButton.SetCenterX()
+
 	t.OptionsButton = guicontrols.NewTextButton(t.WinWidth,
 		t.WinHeight,
 		"  Options  ",
@@ -131,13 +161,28 @@ func NewTitleScreen(winWidth, winHeight, winDepth, numBlocks int, gamestate *gam
 		sdl.Color{R: 128, G: 128, B: 128, A: 192},
 		sdl.Color{R: 128, G: 128, B: 192, A: 192},
 		sdl.Color{R: 0, G: 0, B: 255, A: 192},
-		vec3.Vector3{X: 0, Y: float32(t.WinHeight) * 0.65, Z: 0},
+		vec3.Vector3{X: 0, Y: float32(t.WinHeight) * 0.58, Z: 0},
 		0.1,
 		100,
 		t.TextFont,
 		renderer)
 	t.OptionsButton.SetCenterX()
 
+	t.HighScoresButton = guicontrols.NewTextButton(t.WinWidth,
+		t.WinHeight,
+		" High Scores ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(t.WinHeight) * 0.70, Z: 0},
+		0.1,
+		100,
+		t.TextFont,
+		renderer)
+	t.HighScoresButton.SetCenterX()
+
 	t.QuitButton = guicontrols.NewTextButton(t.WinWidth,
 		t.WinHeight,
 		"   Quit!   ",
@@ -156,11 +201,44 @@ func NewTitleScreen(winWidth, winHeight, winDepth, numBlocks int, gamestate *gam
 	return t
 }
 
+// Wake restarts the wait for the demo - it should be called whenever a key is pressed on the title screen
+func (t *TitleScreen) Wake() {
+	t.IdleTimer = 0
+}
+
 // Update updates all the objects on the title screen
 func (t *TitleScreen) Update(time float64) {
 
-	// Change to MainGame if the start button is clicked
-	if t.StartButton.WasLeftClicked == true {
+	// Show the demo once the mouse has been left alone for a while
+	if t.MouseState.X != t.MouseState.PrevX || t.MouseState.Y != t.MouseState.PrevY || t.MouseState.LeftButton == true || t.MouseState.RightButton == true {
+		t.Wake()
+	}
+	if t.CurrentGameState.TransitioningUp == false && t.CurrentGameState.TransitioningDown == false {
+		t.IdleTimer += time
+	}
+	if t.IdleTimer >= t.IdleTime && t.CurrentGameState.TransitioningUp == false {
+		t.Wake()
+		t.DemoScreen.Start()
+		t.MusicPlayer.FutureTune = 0
+		t.CurrentGameState.TransitioningUp = true
+		t.CurrentGameState.ToState = gamestate.Demo
+	}
+
+	// Change to the mode screen if the start button is clicked - a replay that is waiting to be watched goes straight to MainGame instead
+	if t.StartButton.WasLeftClicked == true && t.CurrentGameState.TransitioningUp == false {
+		t.CurrentGameState.TransitioningUp = true
+		if t.GameBoard.Board.PlayingBack == true {
+			t.MusicPlayer.FutureTune = t.MusicPlayer.PastTune
+			t.CurrentGameState.ToState = gamestate.MainGame
+		} else {
+			t.MusicPlayer.FutureTune = 0
+			t.CurrentGameState.ToState = gamestate.ModeSelect
+		}
+	}
+
+	// Start the current puzzle if the puzzles button is clicked
+	if t.PuzzlesButton.WasLeftClicked == true && len(t.GameBoard.Puzzles) > 0 && t.CurrentGameState.TransitioningUp == false {
+		t.GameBoard.StartPuzzle(t.GameBoard.PuzzleIndex)
 		t.MusicPlayer.FutureTune = t.MusicPlayer.PastTune
 		t.CurrentGameState.TransitioningUp = true
 		t.CurrentGameState.ToState = gamestate.MainGame
@@ -173,6 +251,13 @@ func (t *TitleScreen) Update(time float64) {
 		t.CurrentGameState.ToState = gamestate.OptionsScreen
 	}
 
+	// Change to High Scores screen if the high scores button is clicked
+	if t.HighScoresButton.WasLeftClicked == true {
+		t.MusicPlayer.FutureTune = 0
+		t.CurrentGameState.TransitioningUp = true
+		t.CurrentGameState.ToState = gamestate.HighScores
+	}
+
 	// Quit the game if the quit button is clicked
 	if t.QuitButton.WasLeftClicked == true {
 		t.CurrentGameState.TransitioningUp = true
@@ -200,7 +285,9 @@ func (t *TitleScreen) Update(time float64) {
 
 	// Update the buttons
 	t.StartButton.Update(t.MouseState, time)
+	t.PuzzlesButton.Update(t.MouseState, time)
 	t.OptionsButton.Update(t.MouseState, time)
+	t.HighScoresButton.Update(t.MouseState, time)
 	t.QuitButton.Update(t.MouseState, time)
 }
 
@@ -220,6 +307,8 @@ func (t *TitleScreen) Draw(renderer *sdl.Renderer) {
 
 	// Draw the buttons
 	t.StartButton.Draw(renderer)
+	t.PuzzlesButton.Draw(renderer)
 	t.OptionsButton.Draw(renderer)
+	t.HighScoresButton.Draw(renderer)
 	t.QuitButton.Draw(renderer)
 }
@@ -0,0 +1,26 @@
+package userdata
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// GameFolder is the name of the folder the game keeps its files in, inside the user's config directory
+const GameFolder = "PuzzleBlock"
+
+// Path returns the location of 'name' inside the game's folder in the user's config directory, creating any missing folders along the way
+func Path(name string) (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(configDir, GameFolder, name)
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
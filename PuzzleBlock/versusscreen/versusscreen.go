@@ -0,0 +1,415 @@
+package versusscreen
+
+import (
+	"golang-games/PuzzleBlock/ai"
+	"golang-games/PuzzleBlock/boardmodel"
+	"golang-games/PuzzleBlock/font"
+	"golang-games/PuzzleBlock/gameboard"
+	"golang-games/PuzzleBlock/gamestate"
+	"golang-games/PuzzleBlock/gamestatetransition"
+	"golang-games/PuzzleBlock/guicontrols"
+	"golang-games/PuzzleBlock/musicplayer"
+	"golang-games/PuzzleBlock/netplay"
+	"golang-games/PuzzleBlock/soundplayer"
+	"golang-games/PuzzleBlock/sprite"
+	"golang-games/PuzzleBlock/texturedrawing"
+	"golang-games/PuzzleBlock/vec3"
+	"math/rand"
+	"strconv"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Layout of the gameboard of each player - the same play area as the single player game, with narrower side panels
+const (
+	numAcross     = 13
+	numDown       = 10
+	playAreaStart = 4
+	playAreaEnd   = 9
+)
+
+// VersusScreen is a struct that contains all the sprite information for a two player match, with a gameboard for each player side by side
+type VersusScreen struct {
+	CurrentGameState *gamestatetransition.GameStateTransition
+	MouseState       *guicontrols.MouseState
+	MusicPlayer      *musicplayer.MusicPlayer
+	SoundPlayer      *soundplayer.SoundPlayer
+	GameBoard        *gameboard.GameBoard
+	Players          []*gameboard.GameBoard
+	Match            *boardmodel.Versus
+	Session          *netplay.Session
+	CPU              *ai.Player
+	Paused           bool
+	WinWidth         int
+	WinHeight        int
+	Background       *sprite.Sprite
+	Overlay          *texturedrawing.SinglePixelTexture
+	TextFont         *font.TTFFont
+	PlayerTexts      []*font.TTFString
+	PrevNames        []string
+	GarbageTexts     []*font.TTFString
+	PrevGarbage      []int
+	ResultText       *font.TTFString
+	PrevResult       string
+	ErrorText        *font.TTFString
+	PrevError        string
+	RematchButton    *guicontrols.TextButton
+	MainMenuButton   *guicontrols.TextButton
+}
+
+// NewVersusScreen is a versus screen constructor - the players take the rules, levels and block mode of 'mainboard' when a match starts
+func NewVersusScreen(winWidth, winHeight, winDepth int, gamestate *gamestatetransition.GameStateTransition, mousestate *guicontrols.MouseState, mainboard *gameboard.GameBoard, musicplayer *musicplayer.MusicPlayer, soundplayer *soundplayer.SoundPlayer, renderer *sdl.Renderer) *VersusScreen {
+
+	v := &VersusScreen{}
+
+	v.CurrentGameState = gamestate
+
+	v.MouseState = mousestate
+
+	v.MusicPlayer = musicplayer
+
+	v.SoundPlayer = soundplayer
+
+	v.GameBoard = mainboard
+
+	v.WinWidth = winWidth
+	v.WinHeight = winHeight
+
+	// Set the background image
+	v.Background = sprite.NewSprite(
+		"assets/background.png",
+		vec3.Vector3{X: 0, Y: 0, Z: 0},
+		vec3.Vector3{X: 0, Y: 0, Z: 0},
+		1280,
+		720,
+		float64(winWidth)/1280,
+		float64(winHeight)/720,
+		1,
+		1,
+		0,
+		0,
+		true,
+		0,
+		false,
+		renderer)
+
+	// Set the translucent overlay that darkens the match underneath the menu
+	v.Overlay = texturedrawing.NewSinglePixelTexture(sdl.Color{R: 0, G: 0, B: 0, A: 160}, sdl.Rect{X: 0, Y: 0, W: int32(winWidth), H: int32(winHeight)}, renderer)
+
+	// Set the font for the text
+	v.TextFont = font.NewTTFFont("assets/FifteenTwenty-Bold.otf", winWidth, winHeight)
+
+	// Set a gameboard for each player in their half of the window, with the player's name and the garbage waiting to drop on them under the side panels
+	v.Players = make([]*gameboard.GameBoard, 2)
+	v.PlayerTexts = make([]*font.TTFString, len(v.Players))
+	v.PrevNames = make([]string, len(v.Players))
+	v.GarbageTexts = make([]*font.TTFString, len(v.Players))
+	v.PrevGarbage = make([]int, len(v.Players))
+	for k := range v.Players {
+		p := gameboard.NewGameBoard(winWidth, winHeight, winDepth, k*winWidth/2, winWidth/2, gamestate, numAcross, numDown, playAreaStart, playAreaEnd, rand.Int63(), musicplayer, soundplayer, renderer)
+		p.ShowBackground = false
+		v.Players[k] = p
+
+		v.PlayerTexts[k] = font.NewTTFString("P"+strconv.Itoa(k+1),
+			font.FontLarge,
+			sdl.Color{R: 255, G: 255, B: 0, A: 255},
+			vec3.Vector3{X: p.Blocks[9][1].MainSprite.Pos.X, Y: p.Blocks[9][1].MainSprite.Pos.Y, Z: 0},
+			p.TextFont,
+			renderer)
+		v.PrevNames[k] = "P" + strconv.Itoa(k+1)
+
+		v.GarbageTexts[k] = font.NewTTFString("Garbage: 0",
+			font.FontMedium,
+			sdl.Color{R: 255, G: 255, B: 255, A: 255},
+			vec3.Vector3{X: p.Blocks[9][playAreaEnd+1].MainSprite.Pos.X, Y: p.Blocks[9][playAreaEnd+1].MainSprite.Pos.Y, Z: 0},
+			p.TextFont,
+			renderer)
+	}
+
+	// Set the result text - it is filled in by Draw once the match is paused or over
+	v.ResultText = font.NewTTFString(" ",
+		font.FontTitle,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		vec3.Vector3{X: 0, Y: float32(winHeight) * 0.15, Z: 0},
+		v.TextFont,
+		renderer)
+	v.PrevResult = " "
+
+	// Set the text that says why an online match was cut short
+	v.ErrorText = font.NewTTFString(" ",
+		font.FontMedium,
+		sdl.Color{R: 255, G: 255, B: 0, A: 255},
+		vec3.Vector3{X: 0, Y: float32(winHeight) * 0.43, Z: 0},
+		v.TextFont,
+		renderer)
+	v.PrevError = " "
+
+	v.RematchButton = guicontrols.NewTextButton(v.WinWidth,
+		v.WinHeight,
+		"   Rematch   ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(v.WinHeight) * 0.55, Z: 0},
+		0.1,
+		100,
+		v.TextFont,
+		renderer)
+	v.RematchButton.SetCenterX()
+
+	v.MainMenuButton = guicontrols.NewTextButton(v.WinWidth,
+		v.WinHeight,
+		"  Main Menu  ",
+		font.FontLarge,
+		sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		sdl.Color{R: 128, G: 128, B: 128, A: 192},
+		sdl.Color{R: 128, G: 128, B: 192, A: 192},
+		sdl.Color{R: 0, G: 0, B: 255, A: 192},
+		vec3.Vector3{X: 0, Y: float32(v.WinHeight) * 0.70, Z: 0},
+		0.1,
+		100,
+		v.TextFont,
+		renderer)
+	v.MainMenuButton.SetCenterX()
+
+	v.Start()
+
+	return v
+}
+
+// Start starts a new match on this computer - both players are dealt the same blocks, under the rules, levels and block mode set on the options screen
+// The computer plays the second player if a CPU has been set
+func (v *VersusScreen) Start() {
+	v.Leave()
+	v.Match = boardmodel.NewVersus(v.startPlayers(v.Players[0].Board.Rand.Int63(), v.GameBoard.PairMode, v.GameBoard.Rules, v.GameBoard.Levels)...)
+	if v.CPU != nil {
+		v.CPU.Reset()
+	}
+	v.reset()
+}
+
+// StartCPU starts a new match on this computer against the computer, playing at 'difficulty'
+func (v *VersusScreen) StartCPU(difficulty ai.Difficulty) {
+	v.CPU = ai.NewPlayer(v.Players[1].Board, v.Players[1], difficulty, rand.Int63())
+	v.Start()
+}
+
+// StartOnline starts an online match over a session that is ready - the settings of the match come from the host
+func (v *VersusScreen) StartOnline(s *netplay.Session) {
+	v.Leave()
+	v.CPU = nil
+	s.Start(v.startPlayers(s.Settings.Seed, s.Settings.Pairs, s.Settings.Rules, s.Settings.Levels))
+	v.Session = s
+	v.Match = s.Match
+	v.reset()
+}
+
+// Leave closes the session of an online match, if there is one
+func (v *VersusScreen) Leave() {
+	if v.Session != nil {
+		v.Session.Close()
+		v.Session = nil
+	}
+}
+
+// startPlayers starts a new game on the gameboard of every player from 'seed' and returns their boards - matches are always played from the standard setup
+func (v *VersusScreen) startPlayers(seed int64, pairs bool, rules boardmodel.Rules, levels []boardmodel.Level) []*boardmodel.Board {
+	boards := make([]*boardmodel.Board, len(v.Players))
+	for k, p := range v.Players {
+		p.Setup = gameboard.StandardSetup()
+		p.PairMode = pairs
+		p.Rules = rules
+		p.Levels = levels
+		p.Mode = boardmodel.EndlessMode()
+		p.NewGameFromSeed(seed)
+		boards[k] = p.Board
+	}
+	return boards
+}
+
+// reset takes the menu down for a match that has just started
+func (v *VersusScreen) reset() {
+	v.Paused = false
+
+	// Forget clicks from the last time the menu was up
+	v.RematchButton.WasLeftClicked = false
+	v.MainMenuButton.WasLeftClicked = false
+}
+
+// MoveActiveBlock moves the active block of player 'k' - nothing moves while the match is paused or over
+// In an online match either set of keys moves the local player, whose moves are sent to the other player, and against the computer either set moves player 1
+func (v *VersusScreen) MoveActiveBlock(k int, d string) {
+	if v.Paused == true || v.Match.Over == true {
+		return
+	}
+	if v.Session != nil {
+		v.Session.QueueMove(d)
+	} else if v.CPU != nil {
+		v.Players[0].MoveActiveBlock(d)
+	} else {
+		v.Players[k].MoveActiveBlock(d)
+	}
+}
+
+// MenuUp returns true while the menu is shown over the match
+func (v *VersusScreen) MenuUp() bool {
+	return v.Paused == true || v.Match.Over == true || (v.Session != nil && v.Session.Status == netplay.Failed)
+}
+
+// Pause freezes the match and brings up the menu - a match that is over or in the middle of a transition can not be paused
+// An online match can not be frozen, so it plays on underneath the menu
+func (v *VersusScreen) Pause() {
+	if v.Match.Over == false && v.CurrentGameState.TransitioningUp == false && v.CurrentGameState.TransitioningDown == false {
+		v.Paused = true
+
+		// Forget clicks from the last time the menu was up
+		v.RematchButton.WasLeftClicked = false
+		v.MainMenuButton.WasLeftClicked = false
+	}
+}
+
+// Resume goes straight back to the paused match
+func (v *VersusScreen) Resume() {
+	v.Paused = false
+}
+
+// Result returns the text shown over the match while the menu is up - an online match tells the local player how they did
+func (v *VersusScreen) Result() string {
+	if v.Session != nil && v.Session.Status == netplay.Failed {
+		return "Offline"
+	}
+	if v.Match.Over == true {
+		if v.Match.Winner == -1 {
+			return "Draw!"
+		}
+		if v.Session != nil && v.Match.Winner == v.Session.Local {
+			return "You Win!"
+		}
+		if v.Session != nil {
+			return "You Lose"
+		}
+		if v.CPU != nil && v.Match.Winner == 0 {
+			return "You Win!"
+		}
+		if v.CPU != nil {
+			return "CPU Wins!"
+		}
+		return "P" + strconv.Itoa(v.Match.Winner+1) + " Wins!"
+	}
+	if v.Paused == true {
+		return "Paused"
+	}
+	return " "
+}
+
+// PlayerName returns the name shown under the gameboard of player 'k'
+func (v *VersusScreen) PlayerName(k int) string {
+	if v.CPU != nil && k == 1 {
+		return "CPU"
+	}
+	return "P" + strconv.Itoa(k+1)
+}
+
+// Update updates all the objects on the versus screen
+func (v *VersusScreen) Update(time float64) {
+
+	// The menu is up while the match is paused or over - there is no rematch online
+	if v.MenuUp() == true {
+		// Start a new match if the rematch button is clicked
+		if v.Session == nil && v.RematchButton.WasLeftClicked == true && v.CurrentGameState.TransitioningUp == false {
+			v.Start()
+		}
+
+		// Return to the title screen if the main menu button is clicked
+		if v.MainMenuButton.WasLeftClicked == true && v.CurrentGameState.TransitioningUp == false {
+			v.Leave()
+			v.MusicPlayer.FutureTune = 0
+			v.CurrentGameState.TransitioningUp = true
+			v.CurrentGameState.ToState = gamestate.TitleScreen
+		}
+
+		// Update the buttons
+		if v.Session == nil {
+			v.RematchButton.Update(v.MouseState, time)
+		}
+		v.MainMenuButton.Update(v.MouseState, time)
+	}
+
+	if v.Paused == true && v.Session == nil {
+		return
+	}
+
+	// Update the background image
+	v.Background.Update(time)
+
+	// Let the computer press its keys before the match moves on
+	if v.CPU != nil && v.Match.Over == false {
+		v.CPU.Update(time)
+	}
+
+	// Update the rules of the match - the explosions of the last blocks cleared play on once it is over
+	if v.Session != nil {
+		v.Session.Update(time)
+	} else {
+		v.Match.Update(time)
+	}
+	for _, p := range v.Players {
+		p.UpdateSprites(time)
+	}
+}
+
+// Draw draws all the objects on the versus screen
+func (v *VersusScreen) Draw(renderer *sdl.Renderer) {
+
+	// Draw the background
+	v.Background.Draw(renderer)
+
+	// Draw the gameboards
+	for k, p := range v.Players {
+		p.Draw(renderer)
+
+		if p.Board.GarbageIn != v.PrevGarbage[k] {
+			v.GarbageTexts[k].ChangeStringTexture("Garbage: "+strconv.Itoa(p.Board.GarbageIn), font.FontMedium, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+			v.PrevGarbage[k] = p.Board.GarbageIn
+		}
+
+		if v.PlayerName(k) != v.PrevNames[k] {
+			v.PlayerTexts[k].ChangeStringTexture(v.PlayerName(k), font.FontLarge, sdl.Color{R: 255, G: 255, B: 0, A: 255}, renderer)
+			v.PrevNames[k] = v.PlayerName(k)
+		}
+
+		v.PlayerTexts[k].Draw(renderer)
+		v.GarbageTexts[k].Draw(renderer)
+	}
+
+	if v.MenuUp() == false {
+		return
+	}
+
+	// Draw the menu over the match
+	v.Overlay.Draw(renderer)
+
+	if v.Result() != v.PrevResult {
+		v.ResultText.ChangeStringTexture(v.Result(), font.FontTitle, sdl.Color{R: 255, G: 255, B: 255, A: 255}, renderer)
+		v.ResultText.SetCenterX()
+		v.PrevResult = v.Result()
+	}
+	v.ResultText.Draw(renderer)
+
+	if v.Session != nil && v.Session.Err != nil {
+		if v.Session.Err.Error() != v.PrevError {
+			v.ErrorText.ChangeStringTexture(v.Session.Err.Error(), font.FontMedium, sdl.Color{R: 255, G: 255, B: 0, A: 255}, renderer)
+			v.ErrorText.SetCenterX()
+			v.PrevError = v.Session.Err.Error()
+		}
+		v.ErrorText.Draw(renderer)
+	}
+
+	// Draw the buttons
+	if v.Session == nil {
+		v.RematchButton.Draw(renderer)
+	}
+	v.MainMenuButton.Draw(renderer)
+}